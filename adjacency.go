@@ -0,0 +1,125 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrDuplicateId   = errors.New("duplicate id")
+	ErrMissingParent = errors.New("parent id not found")
+	ErrCycle         = errors.New("cycle in parent ids")
+	ErrNoRoot        = errors.New("no root row without a parent id")
+	ErrOrphan        = errors.New("row is not connected to the root")
+)
+
+// AdjacencyRow is one node of a tree stored as id, parent id and sort order,
+// as tree tables in relational databases usually are. The root has an empty
+// ParentId.
+type AdjacencyRow struct {
+	Id       string
+	ParentId string
+	Position int
+}
+
+// RowError reports which input row made an import fail. Row is zero based.
+type RowError struct {
+	Row int
+	Id  string
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d (id %q): %v", e.Row, e.Id, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// ToAdjacencyList returns a row for every node in document order. ParentId
+// is what GetParentId returns and Position is the index among siblings.
+func ToAdjacencyList(jsonTree string) ([]AdjacencyRow, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	var rows []AdjacencyRow
+	root.walk(func(n *node, parent *node, index int, _ int) bool {
+		row := AdjacencyRow{Id: n.id, Position: index}
+		if parent != nil {
+			row.ParentId = parent.id
+		}
+		rows = append(rows, row)
+		return true
+	})
+	return rows, nil
+}
+
+// FromAdjacencyList builds a json tree from rows. Siblings are ordered by
+// Position, ties keeping their input order. Exactly one row must have an
+// empty ParentId.
+func FromAdjacencyList(rows []AdjacencyRow) (string, error) {
+	root, err := nodeFromAdjacencyList(rows)
+	if err != nil {
+		return "", err
+	}
+	return root.String(), nil
+}
+
+func nodeFromAdjacencyList(rows []AdjacencyRow) (*node, error) {
+	nodes := make(map[string]*node, len(rows))
+	rowIndex := make(map[string]int, len(rows))
+	rootRow := -1
+	for i, row := range rows {
+		if _, ok := nodes[row.Id]; ok {
+			return nil, &RowError{Row: i, Id: row.Id, Err: ErrDuplicateId}
+		}
+		nodes[row.Id] = &node{id: row.Id}
+		rowIndex[row.Id] = i
+		if row.ParentId == "" {
+			if rootRow >= 0 {
+				return nil, &RowError{Row: i, Id: row.Id, Err: ErrOrphan}
+			}
+			rootRow = i
+		}
+	}
+	for i, row := range rows {
+		if row.ParentId == "" {
+			continue
+		}
+		if _, ok := nodes[row.ParentId]; !ok {
+			return nil, &RowError{Row: i, Id: row.Id, Err: ErrMissingParent}
+		}
+	}
+	for i, row := range rows {
+		seen := map[string]bool{row.Id: true}
+		for parentId := row.ParentId; parentId != ""; parentId = rows[rowIndex[parentId]].ParentId {
+			if seen[parentId] {
+				return nil, &RowError{Row: i, Id: row.Id, Err: ErrCycle}
+			}
+			seen[parentId] = true
+		}
+	}
+	if rootRow < 0 {
+		return nil, ErrNoRoot
+	}
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return rows[order[a]].Position < rows[order[b]].Position
+	})
+	for _, i := range order {
+		row := rows[i]
+		if row.ParentId == "" {
+			continue
+		}
+		parent := nodes[row.ParentId]
+		parent.children = append(parent.children, nodes[row.Id])
+	}
+	return nodes[rows[rootRow].Id], nil
+}
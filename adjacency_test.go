@@ -0,0 +1,68 @@
+package jsontree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToAdjacencyList(t *testing.T) {
+	rows, _ := ToAdjacencyList(testJsonTree)
+	assert.Equal(t, 14, len(rows))
+	assert.Equal(t, AdjacencyRow{Id: "a", ParentId: "", Position: 0}, rows[0])
+	assert.Equal(t, AdjacencyRow{Id: "d", ParentId: "b", Position: 1}, rows[3])
+	assert.Equal(t, AdjacencyRow{Id: "n", ParentId: "a", Position: 2}, rows[13])
+
+	for _, row := range rows {
+		parentId, _ := GetParentId(testJsonTree, row.Id)
+		assert.Equal(t, parentId, row.ParentId)
+		isFirst, _ := IsFirstChild(testJsonTree, row.Id)
+		assert.Equal(t, isFirst, row.Position == 0)
+	}
+}
+
+func TestToAdjacencyListManySiblings(t *testing.T) {
+	tree := `{"r":[{"c0":[]},{"c1":[]},{"c2":[]},{"c3":[]},{"c4":[]},{"c5":[]},{"c6":[]},{"c7":[]},{"c8":[]},{"c9":[]},{"c10":[]},{"c11":[]}]}`
+	rows, _ := ToAdjacencyList(tree)
+	assert.Equal(t, AdjacencyRow{Id: "c10", ParentId: "r", Position: 10}, rows[11])
+	assert.Equal(t, AdjacencyRow{Id: "c11", ParentId: "r", Position: 11}, rows[12])
+
+	res, _ := FromAdjacencyList(rows)
+	assert.Equal(t, tree, res)
+}
+
+func TestFromAdjacencyList(t *testing.T) {
+	rows, _ := ToAdjacencyList(testJsonTree)
+	res, err := FromAdjacencyList(rows)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	res, _ = FromAdjacencyList([]AdjacencyRow{
+		{Id: "c", ParentId: "a", Position: 20},
+		{Id: "b", ParentId: "a", Position: 10},
+		{Id: "a"},
+		{Id: "d", ParentId: "b"},
+	})
+	assert.Equal(t, `{"a":[{"b":[{"d":[]}]},{"c":[]}]}`, res)
+}
+
+func TestFromAdjacencyListErrors(t *testing.T) {
+	_, err := FromAdjacencyList([]AdjacencyRow{{Id: "a"}, {Id: "b", ParentId: "x"}})
+	assert.True(t, errors.Is(err, ErrMissingParent))
+	var rowErr *RowError
+	assert.True(t, errors.As(err, &rowErr))
+	assert.Equal(t, 1, rowErr.Row)
+
+	_, err = FromAdjacencyList([]AdjacencyRow{{Id: "a"}, {Id: "b", ParentId: "c"}, {Id: "c", ParentId: "b"}})
+	assert.True(t, errors.Is(err, ErrCycle))
+
+	_, err = FromAdjacencyList([]AdjacencyRow{{Id: "a"}, {Id: "b"}})
+	assert.True(t, errors.Is(err, ErrOrphan))
+
+	_, err = FromAdjacencyList([]AdjacencyRow{{Id: "a"}, {Id: "a", ParentId: "a"}})
+	assert.True(t, errors.Is(err, ErrDuplicateId))
+
+	_, err = FromAdjacencyList(nil)
+	assert.True(t, errors.Is(err, ErrNoRoot))
+}
@@ -0,0 +1,123 @@
+package jsontree
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// The functions in this file take trees held as []byte, such as request
+// bodies, and write changed trees straight to an io.Writer such as a
+// response.
+
+// ReadTree reads a whole tree from r. Every function of the package needs
+// the whole tree, so there are no io.Reader variants of the queries.
+func ReadTree(r io.Reader) (string, error) {
+	var b strings.Builder
+	if _, err := io.Copy(&b, r); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// WriteTree writes jsonTree to w without converting it to []byte when w
+// implements io.StringWriter.
+func WriteTree(w io.Writer, jsonTree string) error {
+	_, err := io.WriteString(w, jsonTree)
+	return err
+}
+
+func GetParentIdBytes(jsonTree []byte, key string) (string, error) {
+	return GetParentId(string(jsonTree), key)
+}
+
+func GetDescendantsIdsBytes(jsonTree []byte, key string, childrenOnly bool) ([]string, error) {
+	return GetDescendantsIds(string(jsonTree), key, childrenOnly)
+}
+
+func GetAllSiblingsIdsBytes(jsonTree []byte, id string) ([]string, error) {
+	return GetAllSiblingsIds(string(jsonTree), id)
+}
+
+func GetFirstChildIdBytes(jsonTree []byte, key string) (string, error) {
+	return GetFirstChildId(string(jsonTree), key)
+}
+
+func HasChildrenBytes(jsonTree []byte, key string) (bool, error) {
+	return HasChildren(string(jsonTree), key)
+}
+
+func IsFirstChildBytes(jsonTree []byte, key string) (bool, error) {
+	return IsFirstChild(string(jsonTree), key)
+}
+
+func IsLastChildBytes(jsonTree []byte, key string) (bool, error) {
+	return IsLastChild(string(jsonTree), key)
+}
+
+func GetNextYoungerSiblingIdBytes(jsonTree []byte, id string) (string, error) {
+	return GetNextYoungerSiblingId(string(jsonTree), id)
+}
+
+func GetYoungerSiblingsIdsBytes(jsonTree []byte, key string) ([]string, error) {
+	return GetYoungerSiblingsIds(string(jsonTree), key)
+}
+
+func GetElderSiblingIdBytes(jsonTree []byte, id string) (string, error) {
+	return GetElderSiblingId(string(jsonTree), id)
+}
+
+func GetTopmostAncestorIdBytes(jsonTree []byte) (string, error) {
+	return GetTopmostAncestorId(string(jsonTree))
+}
+
+// GetDescendantsBytes returns the children array of key.
+func GetDescendantsBytes(jsonTree []byte, key string) ([]byte, error) {
+	descendants, err := GetDescendants(string(jsonTree), key)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(descendants), nil
+}
+
+// AddNextToLeafByIdTo is AddNextToLeafById writing the new tree to w.
+// Nothing is written when it fails.
+func AddNextToLeafByIdTo(w io.Writer, jsonTree []byte, id string, insertBranch string, beforeAfter string) error {
+	if beforeAfter != "before" && beforeAfter != "after" {
+		return errors.New("directive must be either before or after")
+	}
+	if _, err := parseTree(insertBranch); err != nil {
+		return err
+	}
+	newJsonTree, err := AddNextToLeafById(string(jsonTree), id, insertBranch, beforeAfter)
+	if err != nil {
+		return err
+	}
+	return WriteTree(w, newJsonTree)
+}
+
+// AddIntoLeafByIdTo is AddIntoLeafById writing the new tree to w. Nothing
+// is written when it fails.
+func AddIntoLeafByIdTo(w io.Writer, jsonTree []byte, id string, insertBranch string, topBottom string) error {
+	if topBottom != "insideBeginning" && topBottom != "insideEnd" {
+		return errors.New("directive must be either insideBeginning or insideEnd")
+	}
+	if _, err := parseTree(insertBranch); err != nil {
+		return err
+	}
+	newJsonTree, err := AddIntoLeafById(string(jsonTree), id, insertBranch, topBottom)
+	if err != nil {
+		return err
+	}
+	return WriteTree(w, newJsonTree)
+}
+
+// RemoveByIdTo is RemoveById writing the new tree to w. Nothing is written
+// when it fails.
+func RemoveByIdTo(w io.Writer, jsonTree []byte, id string) error {
+	newJsonTree, err := RemoveById(string(jsonTree), id)
+	if err != nil {
+		return err
+	}
+	return WriteTree(w, newJsonTree)
+}
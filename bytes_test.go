@@ -0,0 +1,93 @@
+package jsontree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadWriteTree(t *testing.T) {
+	res, err := ReadTree(strings.NewReader(testJsonTree))
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteTree(&buf, testJsonTree))
+	assert.Equal(t, testJsonTree, buf.String())
+}
+
+func TestBytesQueries(t *testing.T) {
+	tree := []byte(testJsonTree)
+
+	id, _ := GetParentIdBytes(tree, "i")
+	assert.Equal(t, "e", id)
+
+	ids, _ := GetDescendantsIdsBytes(tree, "d", false)
+	assert.Equal(t, []string{"e", "f", "g", "h", "i", "j", "k", "l"}, ids)
+
+	ids, _ = GetAllSiblingsIdsBytes(tree, "g")
+	assert.Equal(t, []string{"f", "h", "i"}, ids)
+
+	ids, _ = GetYoungerSiblingsIdsBytes(tree, "g")
+	assert.Equal(t, []string{"h", "i"}, ids)
+
+	id, _ = GetFirstChildIdBytes(tree, "e")
+	assert.Equal(t, "f", id)
+
+	id, _ = GetNextYoungerSiblingIdBytes(tree, "b")
+	assert.Equal(t, "m", id)
+
+	id, _ = GetElderSiblingIdBytes(tree, "l")
+	assert.Equal(t, "k", id)
+
+	id, _ = GetTopmostAncestorIdBytes(tree)
+	assert.Equal(t, "a", id)
+
+	ok, _ := HasChildrenBytes(tree, "n")
+	assert.False(t, ok)
+
+	ok, _ = IsFirstChildBytes(tree, "j")
+	assert.True(t, ok)
+
+	ok, _ = IsLastChildBytes(tree, "l")
+	assert.True(t, ok)
+
+	descendants, _ := GetDescendantsBytes(tree, "i")
+	assert.Equal(t, `[{"j":[]},{"k":[]},{"l":[]}]`, string(descendants))
+	copy(tree, "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx")
+	assert.Equal(t, `[{"j":[]},{"k":[]},{"l":[]}]`, string(descendants))
+}
+
+func TestBytesMutations(t *testing.T) {
+	var buf bytes.Buffer
+	err := AddNextToLeafByIdTo(&buf, []byte(testJsonTree), `l`, `{"w": [{"y":[]}]}`, "after")
+	assert.NoError(t, err)
+	expected, _ := AddNextToLeafById(testJsonTree, `l`, `{"w": [{"y":[]}]}`, "after")
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	AddIntoLeafByIdTo(&buf, []byte(testJsonTree), `b`, `{"xxx":[]}`, "insideEnd")
+	expected, _ = AddIntoLeafById(testJsonTree, `b`, `{"xxx":[]}`, "insideEnd")
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	RemoveByIdTo(&buf, []byte(testJsonTree), `b`)
+	assert.Equal(t, `{"a":[{"m":[]},{"n":[]}]}`, buf.String())
+
+	buf.Reset()
+	err = RemoveByIdTo(&buf, []byte(testJsonTree), `a`)
+	assert.Error(t, err)
+	assert.Equal(t, 0, buf.Len())
+
+	err = AddIntoLeafByIdTo(&buf, []byte(testJsonTree), `b`, `{"x":[]}`, "bogus")
+	assert.Error(t, err)
+	err = AddIntoLeafByIdTo(&buf, []byte(testJsonTree), `n`, `{"x":[]}`, "bogus")
+	assert.Error(t, err)
+	err = AddNextToLeafByIdTo(&buf, []byte(testJsonTree), `b`, `{"x":[]}`, "bogus")
+	assert.Error(t, err)
+	err = AddNextToLeafByIdTo(&buf, []byte(testJsonTree), `b`, `[1]`, "after")
+	assert.Error(t, err)
+	assert.Equal(t, 0, buf.Len())
+}
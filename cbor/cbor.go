@@ -0,0 +1,32 @@
+// Package cbor encodes json trees as CBOR (RFC 8949). The encoding carries
+// exactly the json structure: a map holding the id mapped to an array of
+// child maps. Decoding rebuilds the same tree, so every query answers as it
+// did on the json the bytes were made from.
+package cbor
+
+import (
+	"reflect"
+
+	"github.com/bmilesp/jsontree"
+	fxcbor "github.com/fxamacker/cbor/v2"
+)
+
+var decMode, _ = fxcbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]interface{}(nil))}.DecMode()
+
+// Marshal encodes the tree as CBOR.
+func Marshal(jsonTree string) ([]byte, error) {
+	v, err := jsontree.ToValue(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	return fxcbor.Marshal(v)
+}
+
+// Unmarshal decodes a tree encoded by Marshal back into json.
+func Unmarshal(data []byte) (string, error) {
+	var v interface{}
+	if err := decMode.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+	return jsontree.FromValue(v)
+}
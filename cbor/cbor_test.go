@@ -0,0 +1,27 @@
+package cbor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testJsonTree = `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"m":[]},{"n":[]}]}`
+
+func TestMarshal(t *testing.T) {
+	data, _ := Marshal(`{"a":[{"b":[]}]}`)
+	// {"a": [{"b": []}]}
+	assert.Equal(t, []byte{0xa1, 0x61, 'a', 0x81, 0xa1, 0x61, 'b', 0x80}, data)
+
+	data, _ = Marshal(testJsonTree)
+	assert.Less(t, len(data), len(testJsonTree))
+	res, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	_, err = Unmarshal([]byte{0x80})
+	assert.Error(t, err)
+
+	_, err = Marshal(`[1]`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ClosureRow links an ancestor to one of its descendants. Every node also
+// has a row linking it to itself with Depth 0.
+type ClosureRow struct {
+	Ancestor   string
+	Descendant string
+	Depth      int
+}
+
+// ClosureDiff lists the closure rows to insert and delete to bring a stored
+// closure table from one tree to another.
+type ClosureDiff struct {
+	Inserted []ClosureRow
+	Deleted  []ClosureRow
+}
+
+// ToClosureTable returns the closure rows of every node in document order,
+// each descendant's rows ordered from itself up to the top-most ancestor.
+func ToClosureTable(jsonTree string) ([]ClosureRow, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	var rows []ClosureRow
+	var visit func(n *node, ancestors []string)
+	visit = func(n *node, ancestors []string) {
+		rows = append(rows, ClosureRow{Ancestor: n.id, Descendant: n.id})
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			rows = append(rows, ClosureRow{Ancestor: ancestors[i], Descendant: n.id, Depth: len(ancestors) - i})
+		}
+		ancestors = append(ancestors, n.id)
+		for _, c := range n.children {
+			visit(c, ancestors)
+		}
+	}
+	visit(root, nil)
+	return rows, nil
+}
+
+// FromClosureTable builds a json tree from closure rows. Closure tables do
+// not store sibling order, so siblings are sorted with less when given and
+// otherwise keep the order of their depth 1 rows. Rows that contradict the
+// parent links are reported as errors.
+func FromClosureTable(rows []ClosureRow, less func(a string, b string) bool) (string, error) {
+	var ids []string
+	parents := make(map[string]string)
+	seen := make(map[string]bool)
+	addId := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	var children []string
+	for _, row := range rows {
+		addId(row.Ancestor)
+		addId(row.Descendant)
+		if row.Depth < 0 || (row.Depth == 0) != (row.Ancestor == row.Descendant) {
+			return "", fmt.Errorf("invalid closure row %s -> %s at depth %d", row.Ancestor, row.Descendant, row.Depth)
+		}
+		if row.Depth != 1 {
+			continue
+		}
+		if parent, ok := parents[row.Descendant]; ok && parent != row.Ancestor {
+			return "", fmt.Errorf("id %s has two parents %s and %s", row.Descendant, parent, row.Ancestor)
+		}
+		if _, ok := parents[row.Descendant]; !ok {
+			parents[row.Descendant] = row.Ancestor
+			children = append(children, row.Descendant)
+		}
+	}
+	if len(ids) == 0 {
+		return "", errors.New("no closure rows")
+	}
+	if less != nil {
+		sort.SliceStable(children, func(a, b int) bool {
+			return less(children[a], children[b])
+		})
+	}
+	position := make(map[string]int, len(children))
+	for i, id := range children {
+		position[id] = i
+	}
+	adjacency := make([]AdjacencyRow, 0, len(ids))
+	for _, id := range ids {
+		adjacency = append(adjacency, AdjacencyRow{Id: id, ParentId: parents[id], Position: position[id]})
+	}
+	root, err := nodeFromAdjacencyList(adjacency)
+	if err != nil {
+		return "", err
+	}
+
+	tree := root.String()
+	expected, err := ToClosureTable(tree)
+	if err != nil {
+		return "", err
+	}
+	known := make(map[ClosureRow]bool, len(expected))
+	for _, row := range expected {
+		known[row] = true
+	}
+	for _, row := range rows {
+		if !known[row] {
+			return "", fmt.Errorf("closure row %s -> %s at depth %d does not match the parent links", row.Ancestor, row.Descendant, row.Depth)
+		}
+	}
+	return tree, nil
+}
+
+// DiffClosureTable compares the closure tables of two trees.
+func DiffClosureTable(before string, after string) (ClosureDiff, error) {
+	var diff ClosureDiff
+	beforeRows, err := ToClosureTable(before)
+	if err != nil {
+		return diff, err
+	}
+	afterRows, err := ToClosureTable(after)
+	if err != nil {
+		return diff, err
+	}
+	old := make(map[ClosureRow]bool, len(beforeRows))
+	for _, row := range beforeRows {
+		old[row] = true
+	}
+	current := make(map[ClosureRow]bool, len(afterRows))
+	for _, row := range afterRows {
+		current[row] = true
+		if !old[row] {
+			diff.Inserted = append(diff.Inserted, row)
+		}
+	}
+	for _, row := range beforeRows {
+		if !current[row] {
+			diff.Deleted = append(diff.Deleted, row)
+		}
+	}
+	return diff, nil
+}
+
+// ClosureAddNextToLeafById runs AddNextToLeafById and returns the new tree
+// along with the closure rows to insert. Nothing is deleted by an add.
+func ClosureAddNextToLeafById(jsonTree string, id string, insertBranch string, beforeAfter string) (string, ClosureDiff, error) {
+	newJsonTree, err := AddNextToLeafById(jsonTree, id, insertBranch, beforeAfter)
+	if err != nil {
+		return newJsonTree, ClosureDiff{}, err
+	}
+	diff, err := DiffClosureTable(jsonTree, newJsonTree)
+	return newJsonTree, diff, err
+}
+
+// ClosureAddIntoLeafById runs AddIntoLeafById and returns the new tree along
+// with the closure rows to insert.
+func ClosureAddIntoLeafById(jsonTree string, id string, insertBranch string, topBottom string) (string, ClosureDiff, error) {
+	newJsonTree, err := AddIntoLeafById(jsonTree, id, insertBranch, topBottom)
+	if err != nil {
+		return newJsonTree, ClosureDiff{}, err
+	}
+	diff, err := DiffClosureTable(jsonTree, newJsonTree)
+	return newJsonTree, diff, err
+}
+
+// ClosureRemoveById runs RemoveById and returns the new tree along with the
+// closure rows to delete.
+func ClosureRemoveById(jsonTree string, id string) (string, ClosureDiff, error) {
+	newJsonTree, err := RemoveById(jsonTree, id)
+	if err != nil {
+		return newJsonTree, ClosureDiff{}, err
+	}
+	diff, err := DiffClosureTable(jsonTree, newJsonTree)
+	return newJsonTree, diff, err
+}
@@ -0,0 +1,48 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToClosureTable(t *testing.T) {
+	rows, _ := ToClosureTable(testJsonTreeSimple)
+	assert.Equal(t, []ClosureRow{{"a", "a", 0}, {"b", "b", 0}, {"a", "b", 1}}, rows)
+
+	rows, _ = ToClosureTable(testJsonTree)
+	// 14 self rows plus one row per ancestor of each node
+	assert.Equal(t, 14+41, len(rows))
+	assert.Contains(t, rows, ClosureRow{"a", "j", 5})
+	assert.Contains(t, rows, ClosureRow{"d", "l", 3})
+}
+
+func TestFromClosureTable(t *testing.T) {
+	rows, _ := ToClosureTable(testJsonTree)
+	res, err := FromClosureTable(rows, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	res, _ = FromClosureTable(rows, func(a string, b string) bool { return a > b })
+	assert.Equal(t, `{"a":[{"n":[]},{"m":[]},{"b":[{"d":[{"e":[{"i":[{"l":[]},{"k":[]},{"j":[]}]},{"h":[]},{"g":[]},{"f":[]}]}]},{"c":[]}]}]}`, res)
+
+	_, err = FromClosureTable([]ClosureRow{{"a", "b", 1}, {"c", "b", 1}}, nil)
+	assert.Error(t, err)
+
+	_, err = FromClosureTable([]ClosureRow{{"a", "b", 1}, {"b", "c", 1}, {"b", "c", 2}}, nil)
+	assert.Error(t, err)
+}
+
+func TestClosureMutations(t *testing.T) {
+	_, diff, _ := ClosureAddNextToLeafById(testJsonTree, "n", `{"w": [{"y":[]}]}`, "after")
+	assert.Equal(t, []ClosureRow{{"w", "w", 0}, {"a", "w", 1}, {"y", "y", 0}, {"w", "y", 1}, {"a", "y", 2}}, diff.Inserted)
+	assert.Nil(t, diff.Deleted)
+
+	_, diff, _ = ClosureAddIntoLeafById(testJsonTree, "c", `{"w":[]}`, "insideEnd")
+	assert.Equal(t, []ClosureRow{{"w", "w", 0}, {"c", "w", 1}, {"b", "w", 2}, {"a", "w", 3}}, diff.Inserted)
+
+	res, diff, _ := ClosureRemoveById(testJsonTree, "i")
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]}]}]}]},{"m":[]},{"n":[]}]}`, res)
+	assert.Equal(t, 5+3*6, len(diff.Deleted))
+	assert.Nil(t, diff.Inserted)
+}
@@ -0,0 +1,121 @@
+package jsontree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// The compact encoding stores the shape of the tree as a balanced
+// parentheses bitstring, a 1 when a node opens and a 0 when it closes in
+// pre-order, followed by an id table in the same order:
+//
+//	"JT" 0x01                          magic and version
+//	uvarint                            number of nodes n
+//	ceil(2n/8) bytes                   parentheses, most significant bit first
+//	n times uvarint length + bytes     ids
+//
+// The shape costs two bits per node, so the size is close to that of the
+// ids alone.
+var compactMagic = []byte{'J', 'T', 1}
+
+// EncodeCompact encodes the tree in the compact structural encoding.
+func EncodeCompact(jsonTree string) ([]byte, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	ids := root.ids()
+	bits := make([]byte, (2*len(ids)+7)/8)
+	bit := 0
+	var shape func(n *node)
+	shape = func(n *node) {
+		bits[bit/8] |= 0x80 >> (bit % 8)
+		bit++
+		for _, c := range n.children {
+			shape(c)
+		}
+		bit++
+	}
+	shape(root)
+
+	var buf bytes.Buffer
+	buf.Write(compactMagic)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(ids))))
+	buf.Write(bits)
+	for _, id := range ids {
+		buf.Write(binary.AppendUvarint(nil, uint64(len(id))))
+		buf.WriteString(id)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeCompact decodes bytes made by EncodeCompact back into a json tree.
+func DecodeCompact(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, compactMagic) {
+		return "", errors.New("not a compact tree encoding")
+	}
+	data = data[len(compactMagic):]
+	count, size := binary.Uvarint(data)
+	if size <= 0 || count == 0 || count > uint64(len(data)) {
+		return "", errors.New("invalid compact node count")
+	}
+	data = data[size:]
+	bitsLen := int((2*count + 7) / 8)
+	if len(data) < bitsLen {
+		return "", errors.New("compact shape is truncated")
+	}
+	bits, data := data[:bitsLen], data[bitsLen:]
+
+	nodes := make([]*node, 0, count)
+	var root *node
+	var stack []*node
+	for bit := 0; bit < int(2*count); bit++ {
+		open := bits[bit/8]&(0x80>>(bit%8)) != 0
+		if open {
+			if len(nodes) == int(count) || (root != nil && len(stack) == 0) {
+				return "", errors.New("compact shape does not match node count")
+			}
+			n := &node{}
+			if root == nil {
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			nodes = append(nodes, n)
+			stack = append(stack, n)
+			continue
+		}
+		if len(stack) == 0 {
+			return "", errors.New("compact shape is unbalanced")
+		}
+		stack = stack[:len(stack)-1]
+	}
+	if len(stack) != 0 || len(nodes) != int(count) {
+		return "", errors.New("compact shape is unbalanced")
+	}
+
+	for _, n := range nodes {
+		length, size := binary.Uvarint(data)
+		if size <= 0 || length > uint64(len(data)-size) {
+			return "", errors.New("compact id table is truncated")
+		}
+		n.id = string(data[size : size+int(length)])
+		data = data[size+int(length):]
+	}
+	if len(data) != 0 {
+		return "", errors.New("unexpected bytes after compact id table")
+	}
+	return root.String(), nil
+}
+
+// CompactRatio returns the size of the compact encoding of the tree divided
+// by the size of its json.
+func CompactRatio(jsonTree string) (float64, error) {
+	data, err := EncodeCompact(jsonTree)
+	if err != nil {
+		return 0, err
+	}
+	return float64(len(data)) / float64(len(jsonTree)), nil
+}
@@ -0,0 +1,39 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeCompact(t *testing.T) {
+	data, _ := EncodeCompact(testJsonTreeSimple)
+	// 1100 shape for a(b), then the ids
+	assert.Equal(t, []byte{'J', 'T', 1, 2, 0xc0, 1, 'a', 1, 'b'}, data)
+
+	data, _ = EncodeCompact(testJsonTree)
+	res, err := DecodeCompact(data)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	ratio, _ := CompactRatio(testJsonTree)
+	assert.Equal(t, float64(len(data))/float64(len(testJsonTree)), ratio)
+	assert.Less(t, ratio, 0.5)
+}
+
+func TestDecodeCompactErrors(t *testing.T) {
+	_, err := DecodeCompact([]byte(`{"a":[]}`))
+	assert.Error(t, err)
+
+	// two roots: 1010
+	_, err = DecodeCompact([]byte{'J', 'T', 1, 2, 0xa0, 1, 'a', 1, 'b'})
+	assert.Error(t, err)
+
+	// truncated id table
+	_, err = DecodeCompact([]byte{'J', 'T', 1, 2, 0xc0, 1, 'a', 2, 'b'})
+	assert.Error(t, err)
+
+	// trailing bytes
+	_, err = DecodeCompact([]byte{'J', 'T', 1, 1, 0x80, 1, 'a', 0})
+	assert.Error(t, err)
+}
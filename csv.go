@@ -0,0 +1,125 @@
+package jsontree
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVColumns names the header columns FromCSV reads. Order is optional;
+// without it siblings keep their row order.
+type CSVColumns struct {
+	Id     string
+	Parent string
+	Order  string
+}
+
+var DefaultCSVColumns = CSVColumns{Id: "id", Parent: "parent"}
+
+// FromCSV builds a json tree from parent/child rows. The first record must
+// be a header naming the columns in cols and the root is the row with an
+// empty parent. A leading byte order mark, as written by spreadsheet
+// exports, is skipped. Errors point at the line of the offending row.
+func FromCSV(r io.Reader, cols CSVColumns) (string, error) {
+	buffered := bufio.NewReader(r)
+	if bom, _ := buffered.Peek(3); string(bom) == "\ufeff" {
+		buffered.Discard(3)
+	}
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return "", err
+	}
+	column := func(name string) int {
+		for i, v := range header {
+			if strings.TrimSpace(v) == name {
+				return i
+			}
+		}
+		return -1
+	}
+	idColumn, parentColumn, orderColumn := column(cols.Id), column(cols.Parent), -1
+	if idColumn < 0 {
+		return "", errors.New("csv header has no " + cols.Id + " column")
+	}
+	if parentColumn < 0 {
+		return "", errors.New("csv header has no " + cols.Parent + " column")
+	}
+	if cols.Order != "" {
+		if orderColumn = column(cols.Order); orderColumn < 0 {
+			return "", errors.New("csv header has no " + cols.Order + " column")
+		}
+	}
+
+	var rows []AdjacencyRow
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(i int) (string, error) {
+			if i >= len(record) {
+				return "", fmt.Errorf("line %d: missing column %s", line, header[i])
+			}
+			return strings.TrimSpace(record[i]), nil
+		}
+		row := AdjacencyRow{Position: len(rows)}
+		if row.Id, err = field(idColumn); err != nil {
+			return "", err
+		}
+		if row.ParentId, err = field(parentColumn); err != nil {
+			return "", err
+		}
+		if orderColumn >= 0 {
+			order, err := field(orderColumn)
+			if err != nil {
+				return "", err
+			}
+			if row.Position, err = strconv.Atoi(order); err != nil {
+				return "", fmt.Errorf("line %d: invalid %s %q", line, cols.Order, order)
+			}
+		}
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+	root, err := nodeFromAdjacencyList(rows)
+	if err != nil {
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			return "", fmt.Errorf("line %d: id %q: %w", lines[rowErr.Row], rowErr.Id, rowErr.Err)
+		}
+		return "", err
+	}
+	return root.String(), nil
+}
+
+// ToCSV lists every node in document order with its id, parent id, depth,
+// sibling index and gjson path.
+func ToCSV(jsonTree string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{"id", "parent_id", "depth", "sibling_index", "path"})
+	root.walkInfo(func(_ *node, info NodeInfo) bool {
+		w.Write([]string{info.Id, info.ParentId, strconv.Itoa(info.Depth), strconv.Itoa(info.Index), info.Path})
+		return true
+	})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,54 @@
+package jsontree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromCSV(t *testing.T) {
+	res, err := FromCSV(strings.NewReader("id,parent\na,\nb,a\nc,b\nm,a\n"), DefaultCSVColumns)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[{"b":[{"c":[]}]},{"m":[]}]}`, res)
+
+	res, _ = FromCSV(strings.NewReader("Name,Sort,Parent Name\nm,2,a\na,0,\nb,1,a\n"), CSVColumns{Id: "Name", Parent: "Parent Name", Order: "Sort"})
+	assert.Equal(t, `{"a":[{"b":[]},{"m":[]}]}`, res)
+
+	res, err = FromCSV(strings.NewReader("\ufeffid,parent\na,\nb,a\n"), DefaultCSVColumns)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[{"b":[]}]}`, res)
+
+	res, _ = FromCSV(strings.NewReader("\ufeff\"id\",\"parent\"\r\na,\r\nb,a\r\n"), DefaultCSVColumns)
+	assert.Equal(t, `{"a":[{"b":[]}]}`, res)
+
+	csvTree, _ := ToCSV(testJsonTree)
+	res, _ = FromCSV(strings.NewReader(csvTree), CSVColumns{Id: "id", Parent: "parent_id", Order: "sibling_index"})
+	assert.Equal(t, testJsonTree, res)
+}
+
+func TestFromCSVErrors(t *testing.T) {
+	_, err := FromCSV(strings.NewReader("id,parent\na,\nb,a\nc,x\n"), DefaultCSVColumns)
+	assert.EqualError(t, err, `line 4: id "c": parent id not found`)
+	assert.ErrorIs(t, err, ErrMissingParent)
+
+	_, err = FromCSV(strings.NewReader("id,parent\na,\nb,c\nc,b\n"), DefaultCSVColumns)
+	assert.EqualError(t, err, `line 3: id "b": cycle in parent ids`)
+
+	_, err = FromCSV(strings.NewReader("id,parent\na,\nb,\n"), DefaultCSVColumns)
+	assert.ErrorIs(t, err, ErrOrphan)
+
+	_, err = FromCSV(strings.NewReader("id,parent,pos\na,,x\n"), CSVColumns{Id: "id", Parent: "parent", Order: "pos"})
+	assert.EqualError(t, err, `line 2: invalid pos "x"`)
+
+	_, err = FromCSV(strings.NewReader("key,parent\na,\n"), DefaultCSVColumns)
+	assert.Error(t, err)
+}
+
+func TestToCSV(t *testing.T) {
+	res, _ := ToCSV(testJsonTreeSimple)
+	assert.Equal(t, "id,parent_id,depth,sibling_index,path\na,,0,0,a\nb,a,1,0,a.0.b\n", res)
+
+	res, _ = ToCSV(testJsonTree)
+	assert.Contains(t, res, "\nl,i,5,2,a.0.b.1.d.0.e.3.i.2.l\n")
+}
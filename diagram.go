@@ -0,0 +1,172 @@
+package jsontree
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DiagramOptions controls ExportDOT and ExportMermaid.
+type DiagramOptions struct {
+	// Name of the graph, "tree" when empty.
+	Name string
+	// Label is a text/template executed with the NodeInfo of every node,
+	// for example "{{.Id}} ({{.Depth}})". The id is used when empty.
+	Label string
+	// Cluster lists ids whose subtrees are drawn inside their own box.
+	Cluster []string
+}
+
+type diagram struct {
+	label   *template.Template
+	cluster map[string]bool
+	b       strings.Builder
+}
+
+func newDiagram(opts DiagramOptions) (*diagram, error) {
+	d := &diagram{cluster: make(map[string]bool)}
+	for _, id := range opts.Cluster {
+		d.cluster[id] = true
+	}
+	if opts.Label != "" {
+		t, err := template.New("label").Parse(opts.Label)
+		if err != nil {
+			return nil, err
+		}
+		d.label = t
+	}
+	return d, nil
+}
+
+func (d *diagram) labelOf(info NodeInfo) (string, error) {
+	if d.label == nil {
+		return info.Id, nil
+	}
+	var b strings.Builder
+	if err := d.label.Execute(&b, info); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ExportDOT returns a Graphviz digraph of the tree. Nodes and edges are
+// written in sibling order and ordering=out keeps that order in the layout.
+func ExportDOT(jsonTree string, opts DiagramOptions) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	d, err := newDiagram(opts)
+	if err != nil {
+		return "", err
+	}
+	name := opts.Name
+	if name == "" {
+		name = "tree"
+	}
+	d.b.WriteString("digraph " + dotQuote(name) + " {\n")
+	d.b.WriteString("  ordering=out;\n")
+	var nodes func(n *node, info NodeInfo, indent string) error
+	nodes = func(n *node, info NodeInfo, indent string) error {
+		label, err := d.labelOf(info)
+		if err != nil {
+			return err
+		}
+		if d.cluster[n.id] {
+			d.b.WriteString(indent + "subgraph " + dotQuote("cluster_"+n.id) + " {\n")
+			d.b.WriteString(indent + "  label=" + dotQuote(label) + ";\n")
+			indent += "  "
+		}
+		d.b.WriteString(indent + dotQuote(n.id) + " [label=" + dotQuote(label) + "];\n")
+		for i, c := range n.children {
+			if err := nodes(c, info.child(c, i), indent); err != nil {
+				return err
+			}
+		}
+		if d.cluster[n.id] {
+			indent = indent[:len(indent)-2]
+			d.b.WriteString(indent + "}\n")
+		}
+		return nil
+	}
+	if err := nodes(root, rootInfo(root), "  "); err != nil {
+		return "", err
+	}
+	root.walk(func(n *node, parent *node, _ int, _ int) bool {
+		if parent != nil {
+			d.b.WriteString("  " + dotQuote(parent.id) + " -> " + dotQuote(n.id) + ";\n")
+		}
+		return true
+	})
+	d.b.WriteString("}\n")
+	return d.b.String(), nil
+}
+
+// ExportMermaid returns a Mermaid flowchart of the tree. Mermaid node names
+// are generated as n0, n1... in document order and the ids become labels.
+func ExportMermaid(jsonTree string, opts DiagramOptions) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	d, err := newDiagram(opts)
+	if err != nil {
+		return "", err
+	}
+	names := make(map[*node]string)
+	root.walk(func(n *node, _ *node, _ int, _ int) bool {
+		names[n] = "n" + strconv.Itoa(len(names))
+		return true
+	})
+	if opts.Name != "" {
+		d.b.WriteString("---\ntitle: " + opts.Name + "\n---\n")
+	}
+	d.b.WriteString("flowchart TD\n")
+	subgraphs := 0
+	var nodes func(n *node, info NodeInfo, indent string) error
+	nodes = func(n *node, info NodeInfo, indent string) error {
+		label, err := d.labelOf(info)
+		if err != nil {
+			return err
+		}
+		if d.cluster[n.id] {
+			d.b.WriteString(indent + "subgraph s" + strconv.Itoa(subgraphs) + " [" + mermaidQuote(label) + "]\n")
+			subgraphs++
+			indent += "  "
+		}
+		d.b.WriteString(indent + names[n] + "[" + mermaidQuote(label) + "]\n")
+		for i, c := range n.children {
+			if err := nodes(c, info.child(c, i), indent); err != nil {
+				return err
+			}
+		}
+		if d.cluster[n.id] {
+			indent = indent[:len(indent)-2]
+			d.b.WriteString(indent + "end\n")
+		}
+		return nil
+	}
+	if err := nodes(root, rootInfo(root), "  "); err != nil {
+		return "", err
+	}
+	root.walk(func(n *node, parent *node, _ int, _ int) bool {
+		if parent != nil {
+			d.b.WriteString("  " + names[parent] + " --> " + names[n] + "\n")
+		}
+		return true
+	})
+	return d.b.String(), nil
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return `"` + s + `"`
+}
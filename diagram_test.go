@@ -0,0 +1,52 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportDOT(t *testing.T) {
+	res, _ := ExportDOT(testJsonTreeSimple, DiagramOptions{})
+	assert.Equal(t, `digraph "tree" {
+  ordering=out;
+  "a" [label="a"];
+  "b" [label="b"];
+  "a" -> "b";
+}
+`, res)
+
+	res, _ = ExportDOT(`{"a":[{"b":[{"c":[]}]},{"m":[]}]}`, DiagramOptions{Name: "menu", Label: "{{.Id}}/{{.Index}}", Cluster: []string{"b"}})
+	assert.Equal(t, `digraph "menu" {
+  ordering=out;
+  "a" [label="a/0"];
+  subgraph "cluster_b" {
+    label="b/0";
+    "b" [label="b/0"];
+    "c" [label="c/0"];
+  }
+  "m" [label="m/1"];
+  "a" -> "b";
+  "b" -> "c";
+  "a" -> "m";
+}
+`, res)
+
+	_, err := ExportDOT(testJsonTree, DiagramOptions{Label: "{{.Nope"})
+	assert.Error(t, err)
+}
+
+func TestExportMermaid(t *testing.T) {
+	res, _ := ExportMermaid(`{"a":[{"b":[{"c":[]}]},{"m":[]}]}`, DiagramOptions{Cluster: []string{"b"}, Label: `{{.Id}} "{{.Depth}}"`})
+	assert.Equal(t, `flowchart TD
+  n0["a #quot;0#quot;"]
+  subgraph s0 ["b #quot;1#quot;"]
+    n1["b #quot;1#quot;"]
+    n2["c #quot;2#quot;"]
+  end
+  n3["m #quot;1#quot;"]
+  n0 --> n1
+  n1 --> n2
+  n0 --> n3
+`, res)
+}
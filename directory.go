@@ -0,0 +1,109 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// DirectoryOptions controls FromDirectory.
+type DirectoryOptions struct {
+	// Filter decides which entries become nodes. Skipping a directory also
+	// skips everything below it. The root is always included.
+	Filter func(path string, d fs.DirEntry) bool
+	// RelativePathIds uses the slash separated path from the root as the id
+	// instead of the entry name, so equally named entries in different
+	// directories stay unique.
+	RelativePathIds bool
+	// RootId is the id of the root node, which defaults to the name of root.
+	// It is required when root is ".", whose name is not a usable id.
+	RootId string
+}
+
+// FromDirectory walks root in fsys and builds a tree with a node for every
+// file and directory, siblings sorted by name as fs.WalkDir visits them.
+// Ids containing Delimiter cannot be found by the path based functions of
+// the package, so dotted file names are best filtered out or combined with
+// a different Delimiter.
+func FromDirectory(fsys fs.FS, root string, opts DirectoryOptions) (string, error) {
+	if root == "." && opts.RootId == "" {
+		return "", errors.New("a root of . needs a RootId")
+	}
+	nodes := make(map[string]*node)
+	seen := make(map[string]string)
+	var top *node
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != root && opts.Filter != nil && !opts.Filter(p, d) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		id := d.Name()
+		if p == root && opts.RootId != "" {
+			id = opts.RootId
+		}
+		if opts.RelativePathIds && p != root {
+			rel := p
+			if root != "." {
+				rel = p[len(root)+1:]
+			}
+			id = rel
+		}
+		if other, ok := seen[id]; ok {
+			return fmt.Errorf("%s and %s both have id %s: %w", other, p, id, ErrDuplicateId)
+		}
+		seen[id] = p
+		n := &node{id: id}
+		nodes[p] = n
+		if p == root {
+			top = n
+			return nil
+		}
+		parent, ok := nodes[path.Dir(p)]
+		if !ok {
+			return errors.New("no parent directory for " + p)
+		}
+		parent.children = append(parent.children, n)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return top.String(), nil
+}
+
+// Materialize creates a directory for every node of the tree inside dir,
+// the top-most ancestor becoming dir's only new child. The last path element
+// of each id is used as the directory name, so trees built with
+// RelativePathIds come back out with the same layout.
+func Materialize(jsonTree string, dir string) error {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return err
+	}
+	var create func(n *node, parentDir string) error
+	create = func(n *node, parentDir string) error {
+		name := path.Base(n.id)
+		if name == "." || name == ".." || name == "/" || n.id == "" {
+			return errors.New("id " + n.id + " cannot be used as a directory name")
+		}
+		p := filepath.Join(parentDir, name)
+		if err := os.MkdirAll(p, 0o755); err != nil {
+			return err
+		}
+		for _, c := range n.children {
+			if err := create(c, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return create(root, dir)
+}
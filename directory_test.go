@@ -0,0 +1,58 @@
+package jsontree
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testAssets = fstest.MapFS{
+	"assets/icons/small/x":  {},
+	"assets/icons/large":    {Mode: fs.ModeDir},
+	"assets/photos/small/y": {},
+	"assets/readme":         {},
+}
+
+func TestFromDirectory(t *testing.T) {
+	res, err := FromDirectory(testAssets, "assets", DirectoryOptions{RelativePathIds: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"assets":[{"icons":[{"icons/large":[]},{"icons/small":[{"icons/small/x":[]}]}]},{"photos":[{"photos/small":[{"photos/small/y":[]}]}]},{"readme":[]}]}`, res)
+
+	_, err = FromDirectory(testAssets, "assets", DirectoryOptions{})
+	assert.ErrorIs(t, err, ErrDuplicateId)
+
+	res, _ = FromDirectory(testAssets, "assets", DirectoryOptions{Filter: func(p string, d fs.DirEntry) bool {
+		return d.IsDir() && !strings.HasPrefix(p, "assets/photos")
+	}})
+	assert.Equal(t, `{"assets":[{"icons":[{"large":[]},{"small":[]}]}]}`, res)
+
+	_, err = FromDirectory(testAssets, ".", DirectoryOptions{RelativePathIds: true})
+	assert.Error(t, err)
+
+	res, err = FromDirectory(testAssets, ".", DirectoryOptions{RelativePathIds: true, RootId: "site"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"site":[{"assets":[{"assets/icons":[{"assets/icons/large":[]},{"assets/icons/small":[{"assets/icons/small/x":[]}]}]},{"assets/photos":[{"assets/photos/small":[{"assets/photos/small/y":[]}]}]},{"assets/readme":[]}]}]}`, res)
+
+	dir := t.TempDir()
+	assert.NoError(t, Materialize(res, dir))
+	_, err = os.Stat(dir + "/site/assets/icons/small/x")
+	assert.NoError(t, err)
+}
+
+func TestMaterialize(t *testing.T) {
+	dir := t.TempDir()
+	err := Materialize(testJsonTree, dir)
+	assert.NoError(t, err)
+
+	res, err := FromDirectory(os.DirFS(dir), "a", DirectoryOptions{})
+	assert.NoError(t, err)
+	// directories come back sorted by name
+	assert.Equal(t, testJsonTree, res)
+
+	err = Materialize(`{"a":[{"..":[]}]}`, dir)
+	assert.Error(t, err)
+}
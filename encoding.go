@@ -0,0 +1,61 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ToValue returns the tree as the generic values encoding packages work
+// with: a map holding the id mapped to a []interface{} of child maps. It
+// carries exactly the json structure, sibling order included.
+func ToValue(jsonTree string) (map[string]interface{}, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	return root.value(), nil
+}
+
+// FromValue builds a json tree back from the form returned by ToValue, as
+// decoded by an encoding package.
+func FromValue(v interface{}) (string, error) {
+	root, err := nodeFromValue(v)
+	if err != nil {
+		return "", err
+	}
+	return root.String(), nil
+}
+
+func (n *node) value() map[string]interface{} {
+	children := make([]interface{}, len(n.children))
+	for i, c := range n.children {
+		children[i] = c.value()
+	}
+	return map[string]interface{}{n.id: children}
+}
+
+func nodeFromValue(v interface{}) (*node, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return nil, errors.New("expected a map with a single id")
+	}
+	var n *node
+	for id, children := range m {
+		n = &node{id: id}
+		if children == nil {
+			break
+		}
+		list, ok := children.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("children of %s must be an array", id)
+		}
+		for _, child := range list {
+			c, err := nodeFromValue(child)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, c)
+		}
+	}
+	return n, nil
+}
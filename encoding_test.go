@@ -0,0 +1,28 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFromValue(t *testing.T) {
+	v, _ := ToValue(`{"a":[{"b":[]},{"m":[]}]}`)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{
+		map[string]interface{}{"b": []interface{}{}},
+		map[string]interface{}{"m": []interface{}{}},
+	}}, v)
+
+	v, _ = ToValue(testJsonTree)
+	res, err := FromValue(v)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	res, _ = FromValue(map[string]interface{}{"a": nil})
+	assert.Equal(t, `{"a":[]}`, res)
+
+	_, err = FromValue([]interface{}{})
+	assert.Error(t, err)
+	_, err = FromValue(map[string]interface{}{"a": "b"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,312 @@
+package jsontree
+
+import (
+	"errors"
+)
+
+const (
+	opAddNextTo = "addNextTo"
+	opAddInto   = "addInto"
+	opRemove    = "remove"
+	// opInsert puts branch at a given child index of id. It only serves to
+	// undo removals.
+	opInsert = "insert"
+)
+
+// operation is a single replayable mutation of a tree.
+type operation struct {
+	kind      string
+	id        string
+	branch    string
+	directive string
+	index     int
+}
+
+// inverse returns the operation that reverts o once o has been applied to
+// root. root is the tree before o is applied.
+func (o operation) inverse(root *node) (operation, error) {
+	switch o.kind {
+	case opAddNextTo, opAddInto, opInsert:
+		id, err := GetTopmostAncestorId(o.branch)
+		if err != nil {
+			return operation{}, err
+		}
+		return operation{kind: opRemove, id: id}, nil
+	case opRemove:
+		target, parent, index := root.find(o.id)
+		if target == nil {
+			return operation{}, errors.New("no id/path found")
+		}
+		if parent == nil {
+			return operation{}, errors.New("cannot remove top-most ancestor")
+		}
+		return operation{kind: opInsert, id: parent.id, branch: target.String(), index: index}, nil
+	}
+	return operation{}, errors.New("unknown operation " + o.kind)
+}
+
+// applyOperations performs ops in order on jsonTree, returning the new tree
+// only if every one of them succeeds.
+func applyOperations(jsonTree string, ops []operation) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return jsonTree, err
+	}
+	known := knownIds(root)
+	for _, o := range ops {
+		if err := o.applyNode(root, known); err != nil {
+			return jsonTree, err
+		}
+	}
+	return root.String(), nil
+}
+
+func knownIds(root *node) map[string]bool {
+	known := make(map[string]bool)
+	for _, id := range root.ids() {
+		known[id] = true
+	}
+	return known
+}
+
+// applyNode performs o on an in-memory tree. known holds every id in root and
+// is kept up to date so that inserted branches cannot duplicate ids.
+func (o operation) applyNode(root *node, known map[string]bool) error {
+	switch o.kind {
+	case opAddNextTo, opAddInto, opInsert:
+		branch, err := parseTree(o.branch)
+		if err != nil {
+			return err
+		}
+		branchIds := branch.ids()
+		for _, id := range branchIds {
+			if known[id] {
+				return errors.New("id " + id + " already exists in tree")
+			}
+		}
+		target, parent, index := root.find(o.id)
+		if target == nil {
+			return errors.New("no id/path found")
+		}
+		switch {
+		case o.kind == opAddNextTo && (o.directive == "before" || o.directive == "after"):
+			if parent == nil {
+				return errors.New("cannot add next to top-most ancestor")
+			}
+			if o.directive == "after" {
+				index++
+			}
+			parent.insertChild(index, branch)
+		case o.kind == opAddInto && o.directive == "insideBeginning":
+			target.insertChild(0, branch)
+		case o.kind == opAddInto && o.directive == "insideEnd":
+			target.insertChild(len(target.children), branch)
+		case o.kind == opInsert:
+			if o.index < 0 || o.index > len(target.children) {
+				return errors.New("child index out of range")
+			}
+			target.insertChild(o.index, branch)
+		default:
+			return errors.New("invalid directive " + o.directive)
+		}
+		for _, id := range branchIds {
+			known[id] = true
+		}
+		return nil
+	case opRemove:
+		target, parent, index := root.find(o.id)
+		if target == nil {
+			return errors.New("no id/path found")
+		}
+		if parent == nil {
+			return errors.New("cannot remove top-most ancestor")
+		}
+		parent.removeChild(index)
+		for _, id := range target.ids() {
+			delete(known, id)
+		}
+		return nil
+	}
+	return errors.New("unknown operation " + o.kind)
+}
+
+type change struct {
+	forward operation
+	reverse operation
+}
+
+// History wraps a tree and records every mutation made through it so that
+// it can be undone and redone. Only the inverse operations are kept, not
+// copies of the tree.
+type History struct {
+	tree     string
+	maxDepth int
+	undo     [][]change
+	redo     [][]change
+	group    []change
+	grouping bool
+}
+
+// NewHistory starts a history on jsonTree keeping at most maxDepth undoable
+// steps. A maxDepth of 0 or less keeps every step.
+func NewHistory(jsonTree string, maxDepth int) *History {
+	return &History{tree: jsonTree, maxDepth: maxDepth}
+}
+
+func (h *History) Tree() string {
+	return h.tree
+}
+
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+func (h *History) AddNextToLeafById(id string, insertBranch string, beforeAfter string) (string, error) {
+	return h.do(operation{kind: opAddNextTo, id: id, branch: insertBranch, directive: beforeAfter})
+}
+
+func (h *History) AddIntoLeafById(id string, insertBranch string, topBottom string) (string, error) {
+	return h.do(operation{kind: opAddInto, id: id, branch: insertBranch, directive: topBottom})
+}
+
+func (h *History) RemoveById(id string) (string, error) {
+	return h.do(operation{kind: opRemove, id: id})
+}
+
+// MoveById moves id and its descendants next to ("before", "after") or into
+// ("insideBeginning", "insideEnd") targetId as a single undoable step.
+func (h *History) MoveById(id string, targetId string, directive string) (string, error) {
+	if id == targetId {
+		return h.tree, errors.New("cannot move a node relative to itself")
+	}
+	root, err := parseTree(h.tree)
+	if err != nil {
+		return h.tree, err
+	}
+	moved, _, _ := root.find(id)
+	if moved == nil {
+		return h.tree, errors.New("no id/path found")
+	}
+	if target, _, _ := root.find(targetId); target == nil {
+		return h.tree, errors.New("no id/path found")
+	}
+	if target, _, _ := moved.find(targetId); target != nil {
+		return h.tree, errors.New("cannot move a node into its own descendants")
+	}
+	add := operation{id: targetId, branch: moved.String(), directive: directive}
+	switch directive {
+	case "before", "after":
+		add.kind = opAddNextTo
+	case "insideBeginning", "insideEnd":
+		add.kind = opAddInto
+	default:
+		return h.tree, errors.New("directive must be one of before, after, insideBeginning or insideEnd")
+	}
+	return h.do(operation{kind: opRemove, id: id}, add)
+}
+
+// BeginGroup starts collecting the following mutations into a single step,
+// undone and redone together once EndGroup is called.
+func (h *History) BeginGroup() {
+	if h.grouping {
+		return
+	}
+	h.grouping = true
+	h.group = nil
+}
+
+func (h *History) EndGroup() {
+	if !h.grouping {
+		return
+	}
+	h.grouping = false
+	if len(h.group) > 0 {
+		h.push(h.group)
+	}
+	h.group = nil
+}
+
+func (h *History) Undo() (string, error) {
+	if h.grouping {
+		h.EndGroup()
+	}
+	if len(h.undo) == 0 {
+		return h.tree, errors.New("nothing to undo")
+	}
+	step := h.undo[len(h.undo)-1]
+	ops := make([]operation, len(step))
+	for i, c := range step {
+		ops[len(step)-1-i] = c.reverse
+	}
+	tree, err := applyOperations(h.tree, ops)
+	if err != nil {
+		return h.tree, err
+	}
+	h.tree = tree
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, step)
+	return h.tree, nil
+}
+
+func (h *History) Redo() (string, error) {
+	if h.grouping {
+		h.EndGroup()
+	}
+	if len(h.redo) == 0 {
+		return h.tree, errors.New("nothing to redo")
+	}
+	step := h.redo[len(h.redo)-1]
+	ops := make([]operation, len(step))
+	for i, c := range step {
+		ops[i] = c.forward
+	}
+	tree, err := applyOperations(h.tree, ops)
+	if err != nil {
+		return h.tree, err
+	}
+	h.tree = tree
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, step)
+	return h.tree, nil
+}
+
+// do applies ops as a single step. The tree is left untouched and nothing is
+// recorded unless every operation succeeds.
+func (h *History) do(ops ...operation) (string, error) {
+	root, err := parseTree(h.tree)
+	if err != nil {
+		return h.tree, err
+	}
+	known := knownIds(root)
+	step := make([]change, 0, len(ops))
+	for _, o := range ops {
+		reverse, err := o.inverse(root)
+		if err != nil {
+			return h.tree, err
+		}
+		if err := o.applyNode(root, known); err != nil {
+			return h.tree, err
+		}
+		step = append(step, change{forward: o, reverse: reverse})
+	}
+	h.tree = root.String()
+	h.redo = nil
+	if h.grouping {
+		h.group = append(h.group, step...)
+	} else {
+		h.push(step)
+	}
+	return h.tree, nil
+}
+
+func (h *History) push(step []change) {
+	h.undo = append(h.undo, step)
+	if h.maxDepth > 0 && len(h.undo) > h.maxDepth {
+		h.undo = h.undo[len(h.undo)-h.maxDepth:]
+	}
+	h.redo = nil
+}
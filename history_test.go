@@ -0,0 +1,158 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryUndoRedo(t *testing.T) {
+	h := NewHistory(testJsonTree, 0)
+
+	res, _ := h.AddNextToLeafById(`h`, `{"w": [{"y":[]}]}`, "before")
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"w":[{"y":[]}]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"m":[]},{"n":[]}]}`, res)
+
+	res, _ = h.RemoveById(`i`)
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"w":[{"y":[]}]},{"h":[]}]}]}]},{"m":[]},{"n":[]}]}`, res)
+
+	res, _ = h.Undo()
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"w":[{"y":[]}]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"m":[]},{"n":[]}]}`, res)
+
+	res, _ = h.Undo()
+	assert.Equal(t, testJsonTree, res)
+
+	_, err := h.Undo()
+	assert.Error(t, err)
+
+	h.Redo()
+	res, _ = h.Redo()
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"w":[{"y":[]}]},{"h":[]}]}]}]},{"m":[]},{"n":[]}]}`, res)
+
+	_, err = h.Redo()
+	assert.Error(t, err)
+}
+
+func TestHistoryRemoveFirstChild(t *testing.T) {
+	h := NewHistory(testJsonTree, 0)
+
+	res, _ := h.RemoveById(`b`)
+	assert.Equal(t, `{"a":[{"m":[]},{"n":[]}]}`, res)
+
+	res, _ = h.Undo()
+	assert.Equal(t, testJsonTree, res)
+
+	_, err := h.RemoveById(`a`)
+	assert.Error(t, err)
+	assert.Equal(t, testJsonTree, h.Tree())
+}
+
+func TestHistoryMoveById(t *testing.T) {
+	h := NewHistory(testJsonTree, 0)
+
+	res, _ := h.MoveById(`i`, `m`, "insideEnd")
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]}]}]}]},{"m":[{"i":[{"j":[]},{"k":[]},{"l":[]}]}]},{"n":[]}]}`, res)
+
+	res, _ = h.Undo()
+	assert.Equal(t, testJsonTree, res)
+	assert.False(t, h.CanUndo())
+
+	_, err := h.MoveById(`d`, `i`, "after")
+	assert.Error(t, err)
+	assert.Equal(t, testJsonTree, h.Tree())
+}
+
+func TestHistoryGroupAndDepth(t *testing.T) {
+	h := NewHistory(testJsonTree, 2)
+
+	h.BeginGroup()
+	h.RemoveById(`m`)
+	h.RemoveById(`n`)
+	h.EndGroup()
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]}]}`, h.Tree())
+
+	res, _ := h.Undo()
+	assert.Equal(t, testJsonTree, res)
+
+	h.Redo()
+	h.RemoveById(`c`)
+	h.RemoveById(`j`)
+	h.Undo()
+	h.Undo()
+	_, err := h.Undo()
+	assert.Error(t, err)
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]}]}`, h.Tree())
+}
+
+func TestHistoryUndoRemoveManySiblings(t *testing.T) {
+	tree := `{"r":[{"c0":[]},{"c1":[]},{"c2":[]},{"c3":[]},{"c4":[]},{"c5":[]},{"c6":[]},{"c7":[]},{"c8":[]},{"c9":[]},{"c10":[]},{"c11":[]},{"c12":[]}]}`
+	h := NewHistory(tree, 0)
+
+	h.RemoveById(`c12`)
+	res, _ := h.Undo()
+	assert.Equal(t, tree, res)
+
+	h.RemoveById(`c2`)
+	res, _ = h.Undo()
+	assert.Equal(t, tree, res)
+}
+
+func TestHistoryRejectsInvalidChanges(t *testing.T) {
+	h := NewHistory(testJsonTree, 0)
+
+	_, err := h.AddIntoLeafById(`b`, `{"x":[]}`, "bogus")
+	assert.Error(t, err)
+	_, err = h.AddNextToLeafById(`b`, `{"x":[]}`, "insideEnd")
+	assert.Error(t, err)
+	_, err = h.AddIntoLeafById(`zz`, `{"x":[]}`, "insideEnd")
+	assert.Error(t, err)
+	_, err = h.AddIntoLeafById(`b`, `[1]`, "insideEnd")
+	assert.Error(t, err)
+	_, err = h.AddIntoLeafById(`n`, `{"x":[{"c":[]}]}`, "insideEnd")
+	assert.Error(t, err)
+	assert.Equal(t, testJsonTree, h.Tree())
+	assert.False(t, h.CanUndo())
+}
+
+func TestHistoryMoveInGroup(t *testing.T) {
+	h := NewHistory(testJsonTree, 0)
+
+	h.BeginGroup()
+	h.RemoveById(`n`)
+	_, err := h.MoveById(`m`, `zz`, "after")
+	assert.Error(t, err)
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"m":[]}]}`, h.Tree())
+
+	h.MoveById(`m`, `c`, "before")
+	h.EndGroup()
+	assert.Equal(t, `{"a":[{"b":[{"m":[]},{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]}]}`, h.Tree())
+
+	res, _ := h.Undo()
+	assert.Equal(t, testJsonTree, res)
+}
+
+func TestHistoryRedoInGroup(t *testing.T) {
+	tree := `{"a":[{"b":[]},{"m":[]},{"n":[]}]}`
+	h := NewHistory(tree, 0)
+
+	h.RemoveById(`b`)
+	h.Undo()
+	h.BeginGroup()
+	h.RemoveById(`n`)
+	_, err := h.Redo()
+	assert.Error(t, err)
+	h.EndGroup()
+	assert.Equal(t, `{"a":[{"b":[]},{"m":[]}]}`, h.Tree())
+
+	res, err := h.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, tree, res)
+	assert.False(t, h.CanUndo())
+
+	h.RemoveById(`b`)
+	h.Undo()
+	h.BeginGroup()
+	h.RemoveById(`m`)
+	h.Undo()
+	res, _ = h.Redo()
+	assert.Equal(t, `{"a":[{"b":[]},{"n":[]}]}`, res)
+}
@@ -0,0 +1,102 @@
+package jsontree
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseIndented builds a json tree from a plain-text outline with one id per
+// line and one level of indentation per depth. The indentation unit, a tab
+// or a run of spaces, is taken from the first indented line and every other
+// line must use whole multiples of it. Blank lines are skipped. Errors carry
+// the line number they were found on.
+func ParseIndented(r io.Reader) (string, error) {
+	var root *node
+	var stack []*node
+	unit := ""
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" {
+			continue
+		}
+		id := strings.TrimLeft(line, " \t")
+		leading := line[:len(line)-len(id)]
+		depth := 0
+		if leading != "" {
+			if unit == "" {
+				if strings.Contains(leading, "\t") && strings.Contains(leading, " ") {
+					return "", fmt.Errorf("line %d: indentation mixes tabs and spaces", lineNumber)
+				}
+				if strings.HasPrefix(leading, "\t") {
+					unit = "\t"
+				} else {
+					unit = leading
+				}
+			}
+			depth = strings.Count(leading, unit)
+			if strings.Repeat(unit, depth) != leading {
+				return "", fmt.Errorf("line %d: indentation %q is not a multiple of %q", lineNumber, leading, unit)
+			}
+		}
+		if previous, ok := seen[id]; ok {
+			return "", fmt.Errorf("line %d: id %s already on line %d: %w", lineNumber, id, previous, ErrDuplicateId)
+		}
+		seen[id] = lineNumber
+		if depth > len(stack) {
+			return "", fmt.Errorf("line %d: %s is indented more than one level below its parent", lineNumber, id)
+		}
+		n := &node{id: id}
+		stack = stack[:depth]
+		if depth == 0 {
+			if root != nil {
+				return "", fmt.Errorf("line %d: second top level id %s", lineNumber, id)
+			}
+			root = n
+		} else {
+			parent := stack[depth-1]
+			parent.children = append(parent.children, n)
+		}
+		stack = append(stack, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if root == nil {
+		return "", errors.New("no ids found")
+	}
+	return root.String(), nil
+}
+
+// FormatIndented writes the tree one id per line, indenting each level by
+// indent, which defaults to two spaces. Ids ParseIndented would read back
+// differently, empty ones and ones with a line break or leading or trailing
+// spaces or tabs, are an error.
+func FormatIndented(jsonTree string, indent string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	if indent == "" {
+		indent = "  "
+	}
+	var b strings.Builder
+	root.walk(func(n *node, _ *node, _ int, depth int) bool {
+		if n.id == "" || strings.Trim(n.id, " \t") != n.id || strings.ContainsAny(n.id, "\r\n") {
+			err = fmt.Errorf("id %q cannot be written on an indented line", n.id)
+			return false
+		}
+		b.WriteString(strings.Repeat(indent, depth) + n.id + "\n")
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
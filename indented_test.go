@@ -0,0 +1,49 @@
+package jsontree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIndented(t *testing.T) {
+	res, err := ParseIndented(strings.NewReader("a\n  b\n\n    c\n  m\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[{"b":[{"c":[]}]},{"m":[]}]}`, res)
+
+	res, _ = ParseIndented(strings.NewReader("a\n\tb\n\t\tc\n\td\n"))
+	assert.Equal(t, `{"a":[{"b":[{"c":[]}]},{"d":[]}]}`, res)
+
+	_, err = ParseIndented(strings.NewReader("a\n  b\n   c\n"))
+	assert.EqualError(t, err, `line 3: indentation "   " is not a multiple of "  "`)
+
+	_, err = ParseIndented(strings.NewReader("a\n  b\n      c\n"))
+	assert.EqualError(t, err, `line 3: c is indented more than one level below its parent`)
+
+	_, err = ParseIndented(strings.NewReader("a\n\tb\n  c\n"))
+	assert.Error(t, err)
+
+	_, err = ParseIndented(strings.NewReader("a\n  b\n  b\n"))
+	assert.True(t, errors.Is(err, ErrDuplicateId))
+	assert.Contains(t, err.Error(), "line 3")
+
+	_, err = ParseIndented(strings.NewReader("a\nb\n"))
+	assert.Error(t, err)
+}
+
+func TestFormatIndented(t *testing.T) {
+	res, _ := FormatIndented(testJsonTreeSimple, "")
+	assert.Equal(t, "a\n  b\n", res)
+
+	res, _ = FormatIndented(testJsonTree, "\t")
+	back, err := ParseIndented(strings.NewReader(res))
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+
+	for _, id := range []string{" b", "b\t", "line\nbreak", "b\r", ""} {
+		_, err = FormatIndented(`{"a":[{`+quoteJson(id)+`:[]}]}`, "")
+		assert.Error(t, err, id)
+	}
+}
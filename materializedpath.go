@@ -0,0 +1,168 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var MaterializedPathSeparator = "/"
+
+// GetMaterializedPath returns the ids from the top-most ancestor down to id
+// as a path like /a/b/d/e. "%" and the separator are percent-encoded within
+// ids.
+func GetMaterializedPath(jsonTree string, id string) (string, error) {
+	ids, err := getIdChain(jsonTree, id)
+	if err != nil {
+		return "", err
+	}
+	return materializedPath(ids), nil
+}
+
+func materializedPath(ids []string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(MaterializedPathSeparator); i++ {
+		fmt.Fprintf(&encoded, "%%%02X", MaterializedPathSeparator[i])
+	}
+	escape := strings.NewReplacer("%", "%25", MaterializedPathSeparator, encoded.String())
+	var b strings.Builder
+	for _, id := range ids {
+		b.WriteString(MaterializedPathSeparator)
+		b.WriteString(escape.Replace(id))
+	}
+	return b.String()
+}
+
+// GetLtreePath returns the id chain of id as a PostgreSQL ltree label path
+// like a.b.d.e. Characters not allowed in ltree labels are replaced by "_".
+func GetLtreePath(jsonTree string, id string) (string, error) {
+	ids, err := getIdChain(jsonTree, id)
+	if err != nil {
+		return "", err
+	}
+	labels := make([]string, len(ids))
+	for i, v := range ids {
+		labels[i] = LtreeLabel(v)
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// LtreeLabel sanitizes id into a valid ltree label: letters, digits and
+// underscores are kept and anything else becomes "_". Hyphens are replaced
+// too, as ltree only accepts them from PostgreSQL 16.
+func LtreeLabel(id string) string {
+	if id == "" {
+		return "_"
+	}
+	var b strings.Builder
+	for _, r := range id {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// ToMaterializedPaths returns the materialized path of every node in
+// document order, which FromMaterializedPaths turns back into the same tree.
+func ToMaterializedPaths(jsonTree string) ([]string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	var ids []string
+	var visit func(n *node)
+	visit = func(n *node) {
+		ids = append(ids, n.id)
+		paths = append(paths, materializedPath(ids))
+		for _, c := range n.children {
+			visit(c)
+		}
+		ids = ids[:len(ids)-1]
+	}
+	visit(root)
+	return paths, nil
+}
+
+// FromMaterializedPaths builds a tree from paths like /a/b/d. Siblings keep
+// the order in which they first appear and missing ancestors are created as
+// they are met. All paths must start at the same top-most ancestor.
+// Percent-encoded ids are decoded.
+func FromMaterializedPaths(paths []string) (string, error) {
+	var root *node
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(path, MaterializedPathSeparator), MaterializedPathSeparator)
+		if trimmed == "" {
+			return "", errors.New("empty materialized path")
+		}
+		ids := strings.Split(trimmed, MaterializedPathSeparator)
+		for i, id := range ids {
+			if id == "" {
+				return "", errors.New("path " + path + " has an empty id")
+			}
+			decoded, err := url.PathUnescape(id)
+			if err != nil {
+				return "", errors.New("path " + path + ": " + err.Error())
+			}
+			ids[i] = decoded
+		}
+		if root == nil {
+			root = &node{id: ids[0]}
+			seen[root.id] = true
+		} else if root.id != ids[0] {
+			return "", errors.New("path " + path + " does not start at top-most ancestor " + root.id)
+		}
+		current := root
+		for _, id := range ids[1:] {
+			var next *node
+			for _, c := range current.children {
+				if c.id == id {
+					next = c
+					break
+				}
+			}
+			if next == nil {
+				if seen[id] {
+					return "", fmt.Errorf("path %s: id %s: %w", path, id, ErrDuplicateId)
+				}
+				seen[id] = true
+				next = &node{id: id}
+				current.children = append(current.children, next)
+			}
+			current = next
+		}
+	}
+	if root == nil {
+		return "", errors.New("no materialized paths")
+	}
+	return root.String(), nil
+}
+
+// getIdChain returns the ids from the top-most ancestor down to id.
+func getIdChain(jsonTree string, id string) ([]string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	var chain, ids []string
+	root.walk(func(n *node, _ *node, _ int, depth int) bool {
+		if ids != nil {
+			return false
+		}
+		chain = append(chain[:depth], n.id)
+		if n.id == id {
+			ids = append([]string(nil), chain...)
+			return false
+		}
+		return true
+	})
+	if ids == nil {
+		return nil, errors.New("no id/path found")
+	}
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMaterializedPath(t *testing.T) {
+	res, _ := GetMaterializedPath(testJsonTree, "e")
+	assert.Equal(t, `/a/b/d/e`, res)
+
+	res, _ = GetMaterializedPath(testJsonTree, "a")
+	assert.Equal(t, `/a`, res)
+
+	res, _ = GetMaterializedPath(testJsonTree, "k")
+	assert.Equal(t, `/a/b/d/e/i/k`, res)
+
+	res, _ = GetMaterializedPath(`{"home":[{"size/10":[{"50%":[]}]}]}`, "50%")
+	assert.Equal(t, `/home/size%2F10/50%25`, res)
+
+	res, _ = GetMaterializedPath(`{"api":[{"v1.2":[{"users":[]}]}]}`, "users")
+	assert.Equal(t, `/api/v1.2/users`, res)
+
+	_, err := GetMaterializedPath(testJsonTree, "zz")
+	assert.Error(t, err)
+}
+
+func TestGetLtreePath(t *testing.T) {
+	res, _ := GetLtreePath(testJsonTree, "e")
+	assert.Equal(t, `a.b.d.e`, res)
+
+	res, _ = GetLtreePath(`{"home":[{"red shoes":[{"size/10":[]}]}]}`, "size/10")
+	assert.Equal(t, `home.red_shoes.size_10`, res)
+
+	res, _ = GetLtreePath(`{"api":[{"v1.2":[]}]}`, "v1.2")
+	assert.Equal(t, `api.v1_2`, res)
+
+	assert.Equal(t, `_`, LtreeLabel(""))
+	assert.Equal(t, `red_shoes`, LtreeLabel("red-shoes"))
+}
+
+func TestMaterializedPaths(t *testing.T) {
+	paths, _ := ToMaterializedPaths(testJsonTree)
+	assert.Equal(t, `/a/b/d/e/i/j`, paths[9])
+	assert.Equal(t, 14, len(paths))
+
+	res, err := FromMaterializedPaths(paths)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	res, _ = FromMaterializedPaths([]string{"/a/n", "/a/b/c", "/a/m"})
+	assert.Equal(t, `{"a":[{"n":[]},{"b":[{"c":[]}]},{"m":[]}]}`, res)
+
+	_, err = FromMaterializedPaths([]string{"/a/b", "/x/y"})
+	assert.Error(t, err)
+
+	tree := `{"a":[{"x/y":[{"100%":[]}]}]}`
+	paths, _ = ToMaterializedPaths(tree)
+	assert.Equal(t, []string{"/a", "/a/x%2Fy", "/a/x%2Fy/100%25"}, paths)
+	res, _ = FromMaterializedPaths(paths)
+	assert.Equal(t, tree, res)
+
+	_, err = FromMaterializedPaths([]string{"/a/100%"})
+	assert.Error(t, err)
+
+	_, err = FromMaterializedPaths([]string{"/a//b"})
+	assert.Error(t, err)
+
+	_, err = FromMaterializedPaths([]string{"/a/b/x", "/a/c/x"})
+	assert.ErrorIs(t, err, ErrDuplicateId)
+
+	_, err = FromMaterializedPaths([]string{"/a/b", "/a/b/a"})
+	assert.ErrorIs(t, err, ErrDuplicateId)
+
+	MaterializedPathSeparator = "::"
+	res, _ = FromMaterializedPaths([]string{"::a", "::a::b:", "::a::d::"})
+	MaterializedPathSeparator = "/"
+	assert.Equal(t, `{"a":[{"b:":[]},{"d":[]}]}`, res)
+}
@@ -0,0 +1,103 @@
+package jsontree
+
+import (
+	"html/template"
+	"strings"
+)
+
+// MenuOptions controls RenderMenu.
+type MenuOptions struct {
+	ListClass     string
+	ItemClass     string
+	ActiveClass   string // "active" when empty
+	ExpandedClass string // "expanded" when empty
+	// Active is the id of the current page. Its item gets ActiveClass and
+	// each of its ancestors gets ExpandedClass.
+	Active string
+	// Link returns the href and label of a node. Without it the id is the
+	// label and items are not links. An empty href also renders no link.
+	Link func(info NodeInfo) (href string, label string)
+}
+
+type menuList struct {
+	Class string
+	Items []menuItem
+}
+
+type menuItem struct {
+	Class    string
+	Href     string
+	Label    string
+	Children *menuList
+}
+
+var menuTemplate = template.Must(template.New("list").Parse(
+	`<ul{{with .Class}} class="{{.}}"{{end}}>` +
+		`{{range .Items}}<li{{with .Class}} class="{{.}}"{{end}}>` +
+		`{{if .Href}}<a href="{{.Href}}">{{.Label}}</a>{{else}}{{.Label}}{{end}}` +
+		`{{with .Children}}{{template "list" .}}{{end}}` +
+		`</li>{{end}}</ul>`))
+
+// RenderMenu renders the tree as nested <ul><li> markup. Everything coming
+// from the tree or the Link callback goes through html/template escaping,
+// so the result is safe to embed in other templates.
+func RenderMenu(jsonTree string, opts MenuOptions) (template.HTML, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	activeClass := opts.ActiveClass
+	if activeClass == "" {
+		activeClass = "active"
+	}
+	expandedClass := opts.ExpandedClass
+	if expandedClass == "" {
+		expandedClass = "expanded"
+	}
+	expanded := make(map[string]bool)
+	if opts.Active != "" {
+		id := opts.Active
+		for {
+			parentId, err := GetParentId(jsonTree, id)
+			if err != nil {
+				return "", err
+			}
+			if parentId == "" {
+				break
+			}
+			expanded[parentId] = true
+			id = parentId
+		}
+	}
+	var build func(n *node, info NodeInfo) menuItem
+	build = func(n *node, info NodeInfo) menuItem {
+		item := menuItem{Label: n.id}
+		if opts.Link != nil {
+			item.Href, item.Label = opts.Link(info)
+		}
+		var classes []string
+		if opts.ItemClass != "" {
+			classes = append(classes, opts.ItemClass)
+		}
+		if opts.Active != "" && n.id == opts.Active {
+			classes = append(classes, activeClass)
+		}
+		if expanded[n.id] {
+			classes = append(classes, expandedClass)
+		}
+		item.Class = strings.Join(classes, " ")
+		if len(n.children) > 0 {
+			item.Children = &menuList{Class: opts.ListClass}
+			for i, c := range n.children {
+				item.Children.Items = append(item.Children.Items, build(c, info.child(c, i)))
+			}
+		}
+		return item
+	}
+	list := menuList{Class: opts.ListClass, Items: []menuItem{build(root, rootInfo(root))}}
+	var b strings.Builder
+	if err := menuTemplate.Execute(&b, list); err != nil {
+		return "", err
+	}
+	return template.HTML(b.String()), nil
+}
@@ -0,0 +1,36 @@
+package jsontree
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMenu(t *testing.T) {
+	res, _ := RenderMenu(testJsonTreeSimple, MenuOptions{})
+	assert.Equal(t, template.HTML(`<ul><li>a<ul><li>b</li></ul></li></ul>`), res)
+
+	res, _ = RenderMenu(`{"a":[{"b":[{"c":[]}]},{"m":[]}]}`, MenuOptions{
+		ListClass: "nav",
+		ItemClass: "item",
+		Active:    "c",
+		Link: func(info NodeInfo) (string, string) {
+			return "/" + info.Path, "<" + info.Id + ">"
+		},
+	})
+	assert.Equal(t, template.HTML(`<ul class="nav"><li class="item expanded"><a href="/a">&lt;a&gt;</a>`+
+		`<ul class="nav"><li class="item expanded"><a href="/a.0.b">&lt;b&gt;</a>`+
+		`<ul class="nav"><li class="item active"><a href="/a.0.b.0.c">&lt;c&gt;</a></li></ul></li>`+
+		`<li class="item"><a href="/a.1.m">&lt;m&gt;</a></li></ul></li></ul>`), res)
+}
+
+func TestRenderMenuEscaping(t *testing.T) {
+	res, _ := RenderMenu(`{"a":[]}`, MenuOptions{Link: func(info NodeInfo) (string, string) {
+		return "javascript:alert(1)", info.Id
+	}})
+	assert.Equal(t, template.HTML(`<ul><li><a href="#ZgotmplZ">a</a></li></ul>`), res)
+
+	_, err := RenderMenu(testJsonTree, MenuOptions{Active: "zz"})
+	assert.Error(t, err)
+}
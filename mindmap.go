@@ -0,0 +1,109 @@
+package jsontree
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type freeMindNode struct {
+	Text  string         `xml:"TEXT,attr"`
+	Nodes []freeMindNode `xml:"node"`
+}
+
+type freeMindMap struct {
+	XMLName xml.Name       `xml:"map"`
+	Nodes   []freeMindNode `xml:"node"`
+}
+
+// FromFreeMind builds a json tree from a FreeMind .mm document using the
+// TEXT attribute of every node as its id. Icons, fonts and other node
+// decorations are ignored.
+func FromFreeMind(mm string) (string, error) {
+	var m freeMindMap
+	if err := xml.Unmarshal([]byte(mm), &m); err != nil {
+		return "", err
+	}
+	if len(m.Nodes) != 1 {
+		return "", fmt.Errorf("mind map must have exactly one root node, found %d", len(m.Nodes))
+	}
+	seen := make(map[string]bool)
+	var build func(f freeMindNode) (*node, error)
+	build = func(f freeMindNode) (*node, error) {
+		if f.Text == "" {
+			return nil, errors.New("mind map node without TEXT")
+		}
+		if seen[f.Text] {
+			return nil, fmt.Errorf("id %s: %w", f.Text, ErrDuplicateId)
+		}
+		seen[f.Text] = true
+		n := &node{id: f.Text}
+		for _, child := range f.Nodes {
+			c, err := build(child)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, c)
+		}
+		return n, nil
+	}
+	root, err := build(m.Nodes[0])
+	if err != nil {
+		return "", err
+	}
+	return root.String(), nil
+}
+
+// ToFreeMind writes the tree as a FreeMind .mm document, visiting nodes in
+// the same order as GetDescendantsIds.
+func ToFreeMind(jsonTree string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("<map version=\"1.0.1\">\n")
+	var write func(n *node, indent string)
+	write = func(n *node, indent string) {
+		b.WriteString(indent + `<node TEXT="` + xmlEscape(n.id) + `"`)
+		if len(n.children) == 0 {
+			b.WriteString("/>\n")
+			return
+		}
+		b.WriteString(">\n")
+		for _, c := range n.children {
+			write(c, indent+"  ")
+		}
+		b.WriteString(indent + "</node>\n")
+	}
+	write(root, "  ")
+	b.WriteString("</map>\n")
+	return b.String(), nil
+}
+
+// ToPlantUMLMindmap writes the tree in PlantUML mindmap syntax.
+func ToPlantUMLMindmap(jsonTree string) (string, error) {
+	return toPlantUML(jsonTree, "mindmap")
+}
+
+// ToPlantUMLWBS writes the tree in PlantUML work breakdown structure syntax.
+func ToPlantUMLWBS(jsonTree string) (string, error) {
+	return toPlantUML(jsonTree, "wbs")
+}
+
+func toPlantUML(jsonTree string, diagram string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("@start" + diagram + "\n")
+	root.walk(func(n *node, _ *node, _ int, depth int) bool {
+		label := strings.Join(strings.Fields(n.id), " ")
+		b.WriteString(strings.Repeat("*", depth+1) + " " + label + "\n")
+		return true
+	})
+	b.WriteString("@end" + diagram + "\n")
+	return b.String(), nil
+}
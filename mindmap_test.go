@@ -0,0 +1,39 @@
+package jsontree
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeMind(t *testing.T) {
+	res, _ := ToFreeMind(`{"a":[{"b & c":[]}]}`)
+	assert.Equal(t, "<map version=\"1.0.1\">\n  <node TEXT=\"a\">\n    <node TEXT=\"b &amp; c\"/>\n  </node>\n</map>\n", res)
+
+	res, _ = ToFreeMind(testJsonTree)
+	back, err := FromFreeMind(res)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+
+	back, _ = FromFreeMind(`<map version="1.0.1"><node TEXT="Roadmap" ID="ID_1"><icon BUILTIN="idea"/><node TEXT="Q1"/><node TEXT="Q2" POSITION="left"/></node></map>`)
+	assert.Equal(t, `{"Roadmap":[{"Q1":[]},{"Q2":[]}]}`, back)
+
+	_, err = FromFreeMind(`<map><node TEXT="a"/><node TEXT="b"/></map>`)
+	assert.Error(t, err)
+}
+
+func TestPlantUML(t *testing.T) {
+	res, _ := ToPlantUMLMindmap(`{"a":[{"b":[{"c":[]}]},{"m":[]}]}`)
+	assert.Equal(t, "@startmindmap\n* a\n** b\n*** c\n** m\n@endmindmap\n", res)
+
+	res, _ = ToPlantUMLWBS(testJsonTree)
+	var ids []string
+	for _, match := range regexp.MustCompile(`(?m)^\*+ (\S+)$`).FindAllStringSubmatch(res, -1) {
+		ids = append(ids, match[1])
+	}
+	descendantIds, _ := GetDescendantsIds(testJsonTree, "a", false)
+	assert.Equal(t, append([]string{"a"}, descendantIds...), ids)
+	assert.Contains(t, res, "@startwbs\n")
+	assert.Contains(t, res, "\n****** j\n")
+}
@@ -0,0 +1,26 @@
+// Package msgpack encodes json trees as MessagePack, with the same map and
+// array structure as the json.
+package msgpack
+
+import (
+	"github.com/bmilesp/jsontree"
+	vmsgpack "github.com/vmihailenco/msgpack/v5"
+)
+
+// Marshal encodes the tree as MessagePack.
+func Marshal(jsonTree string) ([]byte, error) {
+	v, err := jsontree.ToValue(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	return vmsgpack.Marshal(v)
+}
+
+// Unmarshal decodes a tree encoded by Marshal back into json.
+func Unmarshal(data []byte) (string, error) {
+	var v interface{}
+	if err := vmsgpack.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+	return jsontree.FromValue(v)
+}
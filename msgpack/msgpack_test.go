@@ -0,0 +1,22 @@
+package msgpack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testJsonTree = `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"m":[]},{"n":[]}]}`
+
+func TestMarshal(t *testing.T) {
+	data, _ := Marshal(`{"a":[{"b":[]}]}`)
+	assert.Equal(t, []byte{0x81, 0xa1, 'a', 0x91, 0x81, 0xa1, 'b', 0x90}, data)
+
+	data, _ = Marshal(testJsonTree)
+	res, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	_, err = Unmarshal([]byte{0xa1, 'a'})
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// NestedSetRow is the nested-set interval of a node. Every descendant of a
+// node has Lft and Rgt strictly between the node's own Lft and Rgt. The root
+// has Lft 1 and Depth 0.
+type NestedSetRow struct {
+	Id    string
+	Lft   int
+	Rgt   int
+	Depth int
+}
+
+// NestedSetDiff lists the rows to insert, delete and update to bring a
+// stored nested set from one tree to another.
+type NestedSetDiff struct {
+	Inserted []NestedSetRow
+	Deleted  []NestedSetRow
+	Updated  []NestedSetRow
+}
+
+// ToNestedSet returns the interval of every node, in document order.
+func ToNestedSet(jsonTree string) ([]NestedSetRow, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	var rows []NestedSetRow
+	counter := 0
+	var visit func(n *node, depth int)
+	visit = func(n *node, depth int) {
+		counter++
+		i := len(rows)
+		rows = append(rows, NestedSetRow{Id: n.id, Lft: counter, Depth: depth})
+		for _, c := range n.children {
+			visit(c, depth+1)
+		}
+		counter++
+		rows[i].Rgt = counter
+	}
+	visit(root, 0)
+	return rows, nil
+}
+
+// FromNestedSet builds a json tree from nested-set rows in any order. Depth
+// is not read; the nesting comes from Lft and Rgt alone.
+func FromNestedSet(rows []NestedSetRow) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("no nested set rows")
+	}
+	sorted := make([]NestedSetRow, len(rows))
+	copy(sorted, rows)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Lft < sorted[b].Lft
+	})
+	seen := make(map[string]bool, len(sorted))
+	var root *node
+	var stack []*node
+	var bounds []NestedSetRow
+	for i, row := range sorted {
+		if row.Lft >= row.Rgt {
+			return "", fmt.Errorf("id %s: lft %d must be less than rgt %d", row.Id, row.Lft, row.Rgt)
+		}
+		if i > 0 && row.Lft == sorted[i-1].Lft {
+			return "", fmt.Errorf("id %s: lft %d is used twice", row.Id, row.Lft)
+		}
+		if seen[row.Id] {
+			return "", fmt.Errorf("id %s: %w", row.Id, ErrDuplicateId)
+		}
+		seen[row.Id] = true
+		for len(bounds) > 0 && bounds[len(bounds)-1].Rgt < row.Lft {
+			stack = stack[:len(stack)-1]
+			bounds = bounds[:len(bounds)-1]
+		}
+		n := &node{id: row.Id}
+		if root == nil {
+			root = n
+		} else {
+			if len(stack) == 0 {
+				return "", fmt.Errorf("id %s: lies outside the root interval", row.Id)
+			}
+			parent := bounds[len(bounds)-1]
+			if row.Rgt >= parent.Rgt {
+				return "", fmt.Errorf("id %s: interval %d-%d overlaps %s %d-%d", row.Id, row.Lft, row.Rgt, parent.Id, parent.Lft, parent.Rgt)
+			}
+			stack[len(stack)-1].children = append(stack[len(stack)-1].children, n)
+		}
+		stack = append(stack, n)
+		bounds = append(bounds, row)
+	}
+	return root.String(), nil
+}
+
+// DiffNestedSet compares the nested sets of two trees. Rows are matched by
+// id, so an id that moved shows up in Updated with its new interval.
+func DiffNestedSet(before string, after string) (NestedSetDiff, error) {
+	var diff NestedSetDiff
+	beforeRows, err := ToNestedSet(before)
+	if err != nil {
+		return diff, err
+	}
+	afterRows, err := ToNestedSet(after)
+	if err != nil {
+		return diff, err
+	}
+	old := make(map[string]NestedSetRow, len(beforeRows))
+	for _, row := range beforeRows {
+		old[row.Id] = row
+	}
+	for _, row := range afterRows {
+		prev, ok := old[row.Id]
+		if !ok {
+			diff.Inserted = append(diff.Inserted, row)
+			continue
+		}
+		delete(old, row.Id)
+		if prev != row {
+			diff.Updated = append(diff.Updated, row)
+		}
+	}
+	for _, row := range beforeRows {
+		if _, ok := old[row.Id]; ok {
+			diff.Deleted = append(diff.Deleted, row)
+		}
+	}
+	return diff, nil
+}
+
+// NestedSetAddNextToLeafById runs AddNextToLeafById and returns the new tree
+// along with the interval changes it caused.
+func NestedSetAddNextToLeafById(jsonTree string, id string, insertBranch string, beforeAfter string) (string, NestedSetDiff, error) {
+	newJsonTree, err := AddNextToLeafById(jsonTree, id, insertBranch, beforeAfter)
+	if err != nil {
+		return newJsonTree, NestedSetDiff{}, err
+	}
+	diff, err := DiffNestedSet(jsonTree, newJsonTree)
+	return newJsonTree, diff, err
+}
+
+// NestedSetRemoveById runs RemoveById and returns the new tree along with
+// the interval changes it caused.
+func NestedSetRemoveById(jsonTree string, id string) (string, NestedSetDiff, error) {
+	newJsonTree, err := RemoveById(jsonTree, id)
+	if err != nil {
+		return newJsonTree, NestedSetDiff{}, err
+	}
+	diff, err := DiffNestedSet(jsonTree, newJsonTree)
+	return newJsonTree, diff, err
+}
@@ -0,0 +1,50 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToNestedSet(t *testing.T) {
+	rows, _ := ToNestedSet(testJsonTree)
+	assert.Equal(t, NestedSetRow{Id: "a", Lft: 1, Rgt: 28, Depth: 0}, rows[0])
+	assert.Equal(t, NestedSetRow{Id: "b", Lft: 2, Rgt: 23, Depth: 1}, rows[1])
+	assert.Equal(t, NestedSetRow{Id: "j", Lft: 14, Rgt: 15, Depth: 5}, rows[9])
+	assert.Equal(t, NestedSetRow{Id: "n", Lft: 26, Rgt: 27, Depth: 1}, rows[13])
+
+	rows, _ = ToNestedSet(testJsonTreeSimple)
+	assert.Equal(t, []NestedSetRow{{Id: "a", Lft: 1, Rgt: 4}, {Id: "b", Lft: 2, Rgt: 3, Depth: 1}}, rows)
+}
+
+func TestFromNestedSet(t *testing.T) {
+	rows, _ := ToNestedSet(testJsonTree)
+	rows[0], rows[5] = rows[5], rows[0]
+	res, err := FromNestedSet(rows)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	_, err = FromNestedSet([]NestedSetRow{{Id: "a", Lft: 1, Rgt: 4}, {Id: "b", Lft: 2, Rgt: 5}})
+	assert.Error(t, err)
+
+	_, err = FromNestedSet([]NestedSetRow{{Id: "a", Lft: 1, Rgt: 2}, {Id: "b", Lft: 3, Rgt: 4}})
+	assert.Error(t, err)
+
+	_, err = FromNestedSet([]NestedSetRow{{Id: "a", Lft: 2, Rgt: 1}})
+	assert.Error(t, err)
+}
+
+func TestNestedSetRemoveById(t *testing.T) {
+	res, diff, _ := NestedSetRemoveById(testJsonTree, "m")
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"n":[]}]}`, res)
+	assert.Equal(t, []NestedSetRow{{Id: "m", Lft: 24, Rgt: 25, Depth: 1}}, diff.Deleted)
+	assert.Equal(t, []NestedSetRow{{Id: "a", Lft: 1, Rgt: 26}, {Id: "n", Lft: 24, Rgt: 25, Depth: 1}}, diff.Updated)
+	assert.Nil(t, diff.Inserted)
+}
+
+func TestNestedSetAddNextToLeafById(t *testing.T) {
+	_, diff, _ := NestedSetAddNextToLeafById(testJsonTree, "m", `{"w": [{"y":[]}]}`, "after")
+	assert.Equal(t, []NestedSetRow{{Id: "w", Lft: 26, Rgt: 29, Depth: 1}, {Id: "y", Lft: 27, Rgt: 28, Depth: 2}}, diff.Inserted)
+	assert.Equal(t, []NestedSetRow{{Id: "a", Lft: 1, Rgt: 32}, {Id: "n", Lft: 30, Rgt: 31, Depth: 1}}, diff.Updated)
+	assert.Nil(t, diff.Deleted)
+}
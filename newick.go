@@ -0,0 +1,182 @@
+package jsontree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseNewick builds a json tree from a Newick string such as
+// ((c,(f,g)d)b,m,n)a; keeping the sibling order. Every node needs a unique
+// label to serve as its id. Branch lengths are returned by id; nodes
+// without one are left out of the map. [comments] are skipped.
+func ParseNewick(newick string) (string, map[string]float64, error) {
+	p := &newickParser{s: newick, lengths: make(map[string]float64), seen: make(map[string]bool)}
+	root, err := p.subtree()
+	if err != nil {
+		return "", nil, err
+	}
+	p.skip()
+	if p.pos >= len(p.s) || p.s[p.pos] != ';' {
+		return "", nil, p.errorf("expected ;")
+	}
+	p.pos++
+	p.skip()
+	if p.pos != len(p.s) {
+		return "", nil, p.errorf("unexpected text after ;")
+	}
+	return root.String(), p.lengths, nil
+}
+
+type newickParser struct {
+	s       string
+	pos     int
+	lengths map[string]float64
+	seen    map[string]bool
+}
+
+func (p *newickParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("newick offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// skip moves past whitespace and [comments].
+func (p *newickParser) skip() {
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.pos++
+		case c == '[':
+			end := strings.IndexByte(p.s[p.pos:], ']')
+			if end < 0 {
+				p.pos = len(p.s)
+				return
+			}
+			p.pos += end + 1
+		default:
+			return
+		}
+	}
+}
+
+func (p *newickParser) subtree() (*node, error) {
+	p.skip()
+	var children []*node
+	if p.pos < len(p.s) && p.s[p.pos] == '(' {
+		p.pos++
+		for {
+			child, err := p.subtree()
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+			p.skip()
+			if p.pos >= len(p.s) {
+				return nil, p.errorf("unclosed (")
+			}
+			if p.s[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			if p.s[p.pos] == ')' {
+				p.pos++
+				break
+			}
+			return nil, p.errorf("expected , or )")
+		}
+	}
+	p.skip()
+	label, err := p.label()
+	if err != nil {
+		return nil, err
+	}
+	if label == "" {
+		return nil, p.errorf("node without a label")
+	}
+	if p.seen[label] {
+		return nil, fmt.Errorf("newick offset %d: label %s: %w", p.pos, label, ErrDuplicateId)
+	}
+	p.seen[label] = true
+	p.skip()
+	if p.pos < len(p.s) && p.s[p.pos] == ':' {
+		p.pos++
+		p.skip()
+		start := p.pos
+		for p.pos < len(p.s) && strings.IndexByte("0123456789.eE+-", p.s[p.pos]) >= 0 {
+			p.pos++
+		}
+		length, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid branch length %q", p.s[start:p.pos])
+		}
+		p.lengths[label] = length
+	}
+	return &node{id: label, children: children}, nil
+}
+
+func (p *newickParser) label() (string, error) {
+	if p.pos < len(p.s) && p.s[p.pos] == '\'' {
+		var b strings.Builder
+		p.pos++
+		for {
+			if p.pos >= len(p.s) {
+				return "", p.errorf("unclosed quoted label")
+			}
+			c := p.s[p.pos]
+			p.pos++
+			if c == '\'' {
+				if p.pos < len(p.s) && p.s[p.pos] == '\'' {
+					b.WriteByte('\'')
+					p.pos++
+					continue
+				}
+				return b.String(), nil
+			}
+			b.WriteByte(c)
+		}
+	}
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(newickReserved, rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
+
+const newickReserved = "()[]':;, \t\n\r"
+
+// FormatNewick writes the tree as a Newick string, adding :length to the
+// nodes found in lengths, which may be nil. Labels with reserved characters
+// are quoted.
+func FormatNewick(jsonTree string, lengths map[string]float64) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	var write func(n *node)
+	write = func(n *node) {
+		if len(n.children) > 0 {
+			b.WriteString("(")
+			for i, c := range n.children {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				write(c)
+			}
+			b.WriteString(")")
+		}
+		b.WriteString(newickLabel(n.id))
+		if length, ok := lengths[n.id]; ok {
+			b.WriteString(":" + strconv.FormatFloat(length, 'g', -1, 64))
+		}
+	}
+	write(root)
+	b.WriteString(";")
+	return b.String(), nil
+}
+
+func newickLabel(id string) string {
+	if id != "" && !strings.ContainsAny(id, newickReserved) {
+		return id
+	}
+	return "'" + strings.ReplaceAll(id, "'", "''") + "'"
+}
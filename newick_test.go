@@ -0,0 +1,39 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNewick(t *testing.T) {
+	res, lengths, err := ParseNewick("((c,((f,g,h,(j,k,l)i)e)d)b,m,n)a;")
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+	assert.Equal(t, map[string]float64{}, lengths)
+
+	res, lengths, _ = ParseNewick("( 'Homo sapiens':0.1, 'it''s'[note]:2e-1 )root:0 ;")
+	assert.Equal(t, `{"root":[{"Homo sapiens":[]},{"it's":[]}]}`, res)
+	assert.Equal(t, map[string]float64{"Homo sapiens": 0.1, "it's": 0.2, "root": 0}, lengths)
+
+	_, _, err = ParseNewick("(b,c);")
+	assert.Error(t, err)
+
+	_, _, err = ParseNewick("(b,b)a;")
+	assert.ErrorIs(t, err, ErrDuplicateId)
+
+	_, _, err = ParseNewick("(b,c)a")
+	assert.Error(t, err)
+}
+
+func TestFormatNewick(t *testing.T) {
+	res, _ := FormatNewick(testJsonTree, nil)
+	assert.Equal(t, "((c,((f,g,h,(j,k,l)i)e)d)b,m,n)a;", res)
+
+	res, _ = FormatNewick(`{"root":[{"Homo sapiens":[]},{"it's":[]}]}`, map[string]float64{"Homo sapiens": 0.1})
+	assert.Equal(t, "('Homo sapiens':0.1,'it''s')root;", res)
+
+	back, lengths, _ := ParseNewick(res)
+	assert.Equal(t, `{"root":[{"Homo sapiens":[]},{"it's":[]}]}`, back)
+	assert.Equal(t, map[string]float64{"Homo sapiens": 0.1}, lengths)
+}
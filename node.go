@@ -0,0 +1,182 @@
+package jsontree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	gjson "github.com/tidwall/gjson"
+)
+
+// node is an ordered in-memory form of a {"id":[children]} tree, used where
+// a tree has to be changed or walked many times before being written back.
+type node struct {
+	id       string
+	children []*node
+}
+
+func parseTree(jsonTree string) (*node, error) {
+	if !gjson.Valid(jsonTree) {
+		return nil, errors.New("invalid json tree")
+	}
+	return parseNode(gjson.Parse(jsonTree))
+}
+
+func parseNode(value gjson.Result) (*node, error) {
+	if !value.IsObject() {
+		return nil, errors.New("expected an object of the form {\"id\":[children]}")
+	}
+	var n *node
+	var err error
+	value.ForEach(func(key, children gjson.Result) bool {
+		if n != nil {
+			err = errors.New("expected a single id per object, found " + n.id + " and " + key.String())
+			return false
+		}
+		n = &node{id: key.String()}
+		if children.Type == gjson.Null {
+			return true
+		}
+		if !children.IsArray() {
+			err = errors.New("children of " + n.id + " must be an array")
+			return false
+		}
+		children.ForEach(func(_, child gjson.Result) bool {
+			var c *node
+			c, err = parseNode(child)
+			if err != nil {
+				return false
+			}
+			n.children = append(n.children, c)
+			return true
+		})
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, errors.New("expected an object of the form {\"id\":[children]}")
+	}
+	return n, nil
+}
+
+func (n *node) String() string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+func (n *node) write(b *strings.Builder) {
+	b.WriteString("{")
+	b.WriteString(quoteJson(n.id))
+	b.WriteString(":[")
+	for i, c := range n.children {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		c.write(b)
+	}
+	b.WriteString("]}")
+}
+
+// quoteJson quotes s as a json string without escaping html characters,
+// matching what sjson writes.
+func quoteJson(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// walk visits n and its descendants in document order. Returning false from
+// fn skips the descendants of the visited node.
+func (n *node) walk(fn func(n *node, parent *node, index int, depth int) bool) {
+	n.walkFrom(nil, 0, 0, fn)
+}
+
+func (n *node) walkFrom(parent *node, index int, depth int, fn func(n *node, parent *node, index int, depth int) bool) {
+	if !fn(n, parent, index, depth) {
+		return
+	}
+	for i, c := range n.children {
+		c.walkFrom(n, i, depth+1, fn)
+	}
+}
+
+// find returns the node with id along with its parent and sibling index.
+// The parent is nil for the top-most ancestor.
+func (n *node) find(id string) (found *node, parent *node, index int) {
+	n.walk(func(c *node, p *node, i int, _ int) bool {
+		if found != nil {
+			return false
+		}
+		if c.id == id {
+			found, parent, index = c, p, i
+			return false
+		}
+		return true
+	})
+	return found, parent, index
+}
+
+// NodeInfo describes where a node sits in its tree. Path is the gjson path
+// of the node, as used by the rest of the package.
+type NodeInfo struct {
+	Id       string
+	ParentId string
+	Index    int
+	Depth    int
+	Path     string
+}
+
+// walkInfo is walk with the NodeInfo of every visited node.
+func (n *node) walkInfo(fn func(n *node, info NodeInfo) bool) {
+	var visit func(c *node, info NodeInfo)
+	visit = func(c *node, info NodeInfo) {
+		if !fn(c, info) {
+			return
+		}
+		for i, child := range c.children {
+			visit(child, info.child(child, i))
+		}
+	}
+	visit(n, rootInfo(n))
+}
+
+func rootInfo(n *node) NodeInfo {
+	return NodeInfo{Id: n.id, Path: n.id}
+}
+
+// child returns the NodeInfo of c, the child at index of the node info describes.
+func (info NodeInfo) child(c *node, index int) NodeInfo {
+	return NodeInfo{
+		Id:       c.id,
+		ParentId: info.Id,
+		Index:    index,
+		Depth:    info.Depth + 1,
+		Path:     info.Path + Delimiter + strconv.Itoa(index) + Delimiter + c.id,
+	}
+}
+
+func (n *node) ids() []string {
+	var ids []string
+	n.walk(func(c *node, _ *node, _ int, _ int) bool {
+		ids = append(ids, c.id)
+		return true
+	})
+	return ids
+}
+
+func (n *node) insertChild(index int, child *node) {
+	n.children = append(n.children, nil)
+	copy(n.children[index+1:], n.children[index:])
+	n.children[index] = child
+}
+
+func (n *node) removeChild(index int) {
+	n.children = append(n.children[:index], n.children[index+1:]...)
+}
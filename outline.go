@@ -0,0 +1,197 @@
+package jsontree
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type opmlOutline struct {
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlDocument struct {
+	XMLName  xml.Name      `xml:"opml"`
+	Outlines []opmlOutline `xml:"body>outline"`
+}
+
+// FromOPML builds a json tree from an OPML document. The id of every
+// outline is read from idAttribute, "text" when empty. The body must hold
+// a single top level outline.
+func FromOPML(opml string, idAttribute string) (string, error) {
+	if idAttribute == "" {
+		idAttribute = "text"
+	}
+	var doc opmlDocument
+	if err := xml.Unmarshal([]byte(opml), &doc); err != nil {
+		return "", err
+	}
+	if len(doc.Outlines) != 1 {
+		return "", fmt.Errorf("opml body must have exactly one top level outline, found %d", len(doc.Outlines))
+	}
+	seen := make(map[string]bool)
+	var build func(o opmlOutline) (*node, error)
+	build = func(o opmlOutline) (*node, error) {
+		id, ok := "", false
+		for _, attr := range o.Attrs {
+			if attr.Name.Local == idAttribute {
+				id, ok = attr.Value, true
+				break
+			}
+		}
+		if !ok {
+			return nil, errors.New("outline without a " + idAttribute + " attribute")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("id %s: %w", id, ErrDuplicateId)
+		}
+		seen[id] = true
+		n := &node{id: id}
+		for _, child := range o.Outlines {
+			c, err := build(child)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, c)
+		}
+		return n, nil
+	}
+	root, err := build(doc.Outlines[0])
+	if err != nil {
+		return "", err
+	}
+	return root.String(), nil
+}
+
+// ToOPML writes the tree as an OPML 2.0 document with the ids as outline
+// text.
+func ToOPML(jsonTree string, title string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString("<opml version=\"2.0\">\n  <head>\n    <title>" + xmlEscape(title) + "</title>\n  </head>\n  <body>\n")
+	var write func(n *node, indent string)
+	write = func(n *node, indent string) {
+		b.WriteString(indent + `<outline text="` + xmlEscape(n.id) + `"`)
+		if len(n.children) == 0 {
+			b.WriteString("/>\n")
+			return
+		}
+		b.WriteString(">\n")
+		for _, c := range n.children {
+			write(c, indent+"  ")
+		}
+		b.WriteString(indent + "</outline>\n")
+	}
+	write(root, "    ")
+	b.WriteString("  </body>\n</opml>\n")
+	return b.String(), nil
+}
+
+// FromMarkdownList builds a json tree from a Markdown bullet list. Nesting
+// follows the indentation of the items and lines that are not list items
+// are skipped. The list must have a single top level item.
+func FromMarkdownList(markdown string) (string, error) {
+	type level struct {
+		indent int
+		n      *node
+	}
+	var root *node
+	var stack []level
+	seen := make(map[string]bool)
+	for i, line := range strings.Split(markdown, "\n") {
+		indent, id, ok := markdownItem(line)
+		if !ok {
+			continue
+		}
+		if seen[id] {
+			return "", fmt.Errorf("line %d: id %s: %w", i+1, id, ErrDuplicateId)
+		}
+		seen[id] = true
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		n := &node{id: id}
+		if len(stack) == 0 {
+			if root != nil {
+				return "", fmt.Errorf("line %d: second top level item %s", i+1, id)
+			}
+			root = n
+		} else {
+			parent := stack[len(stack)-1].n
+			parent.children = append(parent.children, n)
+		}
+		stack = append(stack, level{indent: indent, n: n})
+	}
+	if root == nil {
+		return "", errors.New("no list items found")
+	}
+	return root.String(), nil
+}
+
+// markdownItem parses a "- item", "* item", "+ item" or "1. item" line,
+// returning the width of its indentation with tabs counted as four spaces.
+func markdownItem(line string) (int, string, bool) {
+	indent := 0
+	rest := line
+	for len(rest) > 0 && (rest[0] == ' ' || rest[0] == '\t') {
+		if rest[0] == '\t' {
+			indent += 4
+		} else {
+			indent++
+		}
+		rest = rest[1:]
+	}
+	switch {
+	case strings.HasPrefix(rest, "- "), strings.HasPrefix(rest, "* "), strings.HasPrefix(rest, "+ "):
+		rest = rest[2:]
+	default:
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 || !(strings.HasPrefix(rest[digits:], ". ") || strings.HasPrefix(rest[digits:], ") ")) {
+			return 0, "", false
+		}
+		rest = rest[digits+2:]
+	}
+	id := strings.TrimSpace(rest)
+	if id == "" {
+		return 0, "", false
+	}
+	return indent, id, true
+}
+
+// ToMarkdownList writes the tree as a Markdown bullet list indented by two
+// spaces per level. Ids a list item cannot carry, empty ones and ones with a
+// line break or leading or trailing whitespace, are an error.
+func ToMarkdownList(jsonTree string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	root.walk(func(n *node, _ *node, _ int, depth int) bool {
+		if n.id == "" || strings.TrimSpace(n.id) != n.id || strings.ContainsAny(n.id, "\r\n") {
+			err = fmt.Errorf("id %q cannot be written as a list item", n.id)
+			return false
+		}
+		b.WriteString(strings.Repeat("  ", depth) + "- " + n.id + "\n")
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOPML(t *testing.T) {
+	res, _ := ToOPML(testJsonTreeSimple, "Simple & small")
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Simple &amp; small</title>
+  </head>
+  <body>
+    <outline text="a">
+      <outline text="b"/>
+    </outline>
+  </body>
+</opml>
+`, res)
+
+	res, _ = ToOPML(testJsonTree, "")
+	back, err := FromOPML(res, "")
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+
+	back, _ = FromOPML(`<opml version="2.0"><body><outline text="Root" slug="root"><outline text="Shoes" slug="shoes"/><outline text="Hats" slug="hats"/></outline></body></opml>`, "slug")
+	assert.Equal(t, `{"root":[{"shoes":[]},{"hats":[]}]}`, back)
+	id, _ := GetNextYoungerSiblingId(back, "shoes")
+	assert.Equal(t, "hats", id)
+
+	_, err = FromOPML(`<opml><body><outline text="a"/><outline text="b"/></body></opml>`, "")
+	assert.Error(t, err)
+
+	_, err = FromOPML(`<opml><body><outline text="a"><outline text="a"/></outline></body></opml>`, "")
+	assert.Error(t, err)
+}
+
+func TestMarkdownList(t *testing.T) {
+	res, _ := ToMarkdownList(testJsonTreeSimple)
+	assert.Equal(t, "- a\n  - b\n", res)
+
+	res, _ = ToMarkdownList(testJsonTree)
+	back, err := FromMarkdownList(res)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+
+	back, _ = FromMarkdownList("# Menu\n\n* a\n    1. b\n    2. c\n\t  * d\n")
+	assert.Equal(t, `{"a":[{"b":[]},{"c":[{"d":[]}]}]}`, back)
+
+	_, err = FromMarkdownList("- a\n- b\n")
+	assert.Error(t, err)
+
+	tree := `{"a":[{"- b":[]},{"1. c":[]},{"* d":[]}]}`
+	res, _ = ToMarkdownList(tree)
+	back, _ = FromMarkdownList(res)
+	assert.Equal(t, tree, back)
+
+	for _, id := range []string{"line\nbreak", " b", "b ", ""} {
+		_, err = ToMarkdownList(`{"a":[{` + quoteJson(id) + `:[]}]}`)
+		assert.Error(t, err, id)
+	}
+}
@@ -0,0 +1,83 @@
+package jsontree
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RenderOptions controls the text produced by Render.
+type RenderOptions struct {
+	// ASCII draws connectors with |-- and `-- instead of box-drawing characters.
+	ASCII bool
+	// MaxDepth stops rendering below this depth, the top-most ancestor
+	// being depth 0. 0 or less renders the whole tree.
+	MaxDepth int
+	// ShowIndex appends the sibling index of every node as [n].
+	ShowIndex bool
+	// ShowPath appends the gjson path of every node.
+	ShowPath bool
+	// Highlight marks the line of this id with HighlightMarker.
+	Highlight       string
+	HighlightMarker string
+}
+
+type connectors struct {
+	branch, last, pipe, blank string
+}
+
+var unicodeConnectors = connectors{branch: "├── ", last: "└── ", pipe: "│   ", blank: "    "}
+var asciiConnectors = connectors{branch: "|-- ", last: "`-- ", pipe: "|   ", blank: "    "}
+
+// Render draws the tree one node per line the way tree(1) draws
+// directories.
+func Render(jsonTree string, opts RenderOptions) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	c := unicodeConnectors
+	if opts.ASCII {
+		c = asciiConnectors
+	}
+	marker := opts.HighlightMarker
+	if marker == "" {
+		marker = "<--"
+	}
+	var b strings.Builder
+	var visit func(n *node, index int, depth int, path string, prefix string, connector string)
+	visit = func(n *node, index int, depth int, path string, prefix string, connector string) {
+		b.WriteString(prefix)
+		b.WriteString(connector)
+		b.WriteString(n.id)
+		if opts.ShowIndex {
+			b.WriteString(" [" + strconv.Itoa(index) + "]")
+		}
+		if opts.ShowPath {
+			b.WriteString(" (" + path + ")")
+		}
+		if opts.Highlight != "" && opts.Highlight == n.id {
+			b.WriteString(" " + marker)
+		}
+		b.WriteString("\n")
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+			return
+		}
+		childPrefix := prefix
+		switch connector {
+		case c.branch:
+			childPrefix += c.pipe
+		case c.last:
+			childPrefix += c.blank
+		}
+		for i, child := range n.children {
+			childConnector := c.branch
+			if i == len(n.children)-1 {
+				childConnector = c.last
+			}
+			childPath := path + Delimiter + strconv.Itoa(i) + Delimiter + child.id
+			visit(child, i, depth+1, childPath, childPrefix, childConnector)
+		}
+	}
+	visit(root, 0, 0, root.id, "", "")
+	return b.String(), nil
+}
@@ -0,0 +1,37 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	res, _ := Render(testJsonTree, RenderOptions{})
+	assert.Equal(t, `a
+├── b
+│   ├── c
+│   └── d
+│       └── e
+│           ├── f
+│           ├── g
+│           ├── h
+│           └── i
+│               ├── j
+│               ├── k
+│               └── l
+├── m
+└── n
+`, res)
+
+	res, _ = Render(testJsonTree, RenderOptions{ASCII: true, MaxDepth: 2, Highlight: "d"})
+	assert.Equal(t, "a\n|-- b\n|   |-- c\n|   `-- d <--\n|-- m\n`-- n\n", res)
+}
+
+func TestRenderIndexAndPath(t *testing.T) {
+	res, _ := Render(testJsonTreeSimple, RenderOptions{ShowIndex: true, ShowPath: true})
+	assert.Equal(t, "a [0] (a)\n└── b [0] (a.0.b)\n", res)
+
+	res, _ = Render(testJsonTree, RenderOptions{ShowPath: true, Highlight: "l", HighlightMarker: "*"})
+	assert.Contains(t, res, "└── l (a.0.b.1.d.0.e.3.i.2.l) *\n")
+}
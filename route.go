@@ -0,0 +1,81 @@
+package jsontree
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	gjson "github.com/tidwall/gjson"
+)
+
+// Route is a node reached by ResolveRoute.
+type Route struct {
+	Id   string
+	Path string
+}
+
+// RouteNotFoundError is returned by ResolveRoute when only part of a route
+// matches. Matched is the deepest node reached, empty when not even the
+// top-most ancestor matched, and Prefix the part of the route it covers.
+type RouteNotFoundError struct {
+	Route   string
+	Prefix  string
+	Matched Route
+}
+
+func (e *RouteNotFoundError) Error() string {
+	if e.Prefix == "" {
+		return "route " + e.Route + " not found"
+	}
+	return "route " + e.Route + " not found, deepest match is " + e.Prefix
+}
+
+// ResolveRoute follows a slash separated route such as "a/b/d" from the
+// top-most ancestor down, matching one id per level, and returns the id and
+// gjson path of the node it ends at.
+func ResolveRoute(jsonTree string, route string) (Route, error) {
+	if !gjson.Valid(jsonTree) {
+		return Route{}, errors.New("invalid json tree")
+	}
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	notFound := &RouteNotFoundError{Route: route}
+	var matched []string
+	var current Route
+	var children gjson.Result
+	found := false
+	gjson.Parse(jsonTree).ForEach(func(key, value gjson.Result) bool {
+		if key.String() == segments[0] {
+			current = Route{Id: key.String(), Path: key.String()}
+			children = value
+			found = true
+		}
+		return false
+	})
+	if !found {
+		return Route{}, notFound
+	}
+	matched = append(matched, segments[0])
+	for _, segment := range segments[1:] {
+		found = false
+		index := 0
+		children.ForEach(func(_, child gjson.Result) bool {
+			child.ForEach(func(key, value gjson.Result) bool {
+				if key.String() == segment {
+					current = Route{Id: segment, Path: current.Path + Delimiter + strconv.Itoa(index) + Delimiter + segment}
+					children = value
+					found = true
+				}
+				return false
+			})
+			index++
+			return !found
+		})
+		if !found {
+			notFound.Matched = current
+			notFound.Prefix = strings.Join(matched, "/")
+			return Route{}, notFound
+		}
+		matched = append(matched, segment)
+	}
+	return current, nil
+}
@@ -0,0 +1,36 @@
+package jsontree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bmiles-development/gjson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRoute(t *testing.T) {
+	res, err := ResolveRoute(testJsonTree, "a/b/d")
+	assert.NoError(t, err)
+	assert.Equal(t, Route{Id: "d", Path: "a.0.b.1.d"}, res)
+
+	res, _ = ResolveRoute(testJsonTree, "/a/b/d/e/i/k/")
+	assert.Equal(t, Route{Id: "k", Path: "a.0.b.1.d.0.e.3.i.1.k"}, res)
+	assert.Equal(t, `[]`, gjson.Get(testJsonTree, res.Path).Raw)
+
+	res, _ = ResolveRoute(testJsonTree, "a")
+	assert.Equal(t, Route{Id: "a", Path: "a"}, res)
+}
+
+func TestResolveRouteNotFound(t *testing.T) {
+	_, err := ResolveRoute(testJsonTree, "a/b/e")
+	var notFound *RouteNotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "a/b", notFound.Prefix)
+	assert.Equal(t, Route{Id: "b", Path: "a.0.b"}, notFound.Matched)
+	assert.EqualError(t, err, "route a/b/e not found, deepest match is a/b")
+
+	_, err = ResolveRoute(testJsonTree, "x/b")
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "", notFound.Prefix)
+	assert.Equal(t, Route{}, notFound.Matched)
+}
@@ -0,0 +1,142 @@
+package jsontree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSExpression builds a json tree from a Lisp-style S-expression where
+// a list is an id followed by its children and a bare atom is a leaf:
+//
+//	(a (b c (d e)) m n)
+//
+// Atoms may be written as "quoted strings" with Go escapes.
+func ParseSExpression(sexpr string) (string, error) {
+	p := &sexprParser{s: sexpr, seen: make(map[string]bool)}
+	root, err := p.expression()
+	if err != nil {
+		return "", err
+	}
+	p.skip()
+	if p.pos != len(p.s) {
+		return "", p.errorf("unexpected text after expression")
+	}
+	return root.String(), nil
+}
+
+type sexprParser struct {
+	s    string
+	pos  int
+	seen map[string]bool
+}
+
+func (p *sexprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("s-expression offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// skip moves past whitespace and ; comments.
+func (p *sexprParser) skip() {
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.pos++
+		case c == ';':
+			end := strings.IndexByte(p.s[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.s)
+				return
+			}
+			p.pos += end + 1
+		default:
+			return
+		}
+	}
+}
+
+func (p *sexprParser) expression() (*node, error) {
+	p.skip()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end of input")
+	}
+	if p.s[p.pos] != '(' {
+		return p.atom()
+	}
+	p.pos++
+	p.skip()
+	n, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skip()
+		if p.pos >= len(p.s) {
+			return nil, p.errorf("unclosed (")
+		}
+		if p.s[p.pos] == ')' {
+			p.pos++
+			return n, nil
+		}
+		child, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, child)
+	}
+}
+
+func (p *sexprParser) atom() (*node, error) {
+	var id string
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		prefix, err := strconv.QuotedPrefix(p.s[p.pos:])
+		if err != nil {
+			return nil, p.errorf("invalid quoted atom")
+		}
+		id, _ = strconv.Unquote(prefix)
+		p.pos += len(prefix)
+	} else {
+		start := p.pos
+		for p.pos < len(p.s) && !strings.ContainsRune(sexprReserved, rune(p.s[p.pos])) {
+			p.pos++
+		}
+		id = p.s[start:p.pos]
+		if id == "" {
+			return nil, p.errorf("expected an atom")
+		}
+	}
+	if p.seen[id] {
+		return nil, fmt.Errorf("s-expression offset %d: atom %s: %w", p.pos, id, ErrDuplicateId)
+	}
+	p.seen[id] = true
+	return &node{id: id}, nil
+}
+
+const sexprReserved = "()\"; \t\n\r"
+
+// FormatSExpression writes the tree as a single line S-expression.
+func FormatSExpression(jsonTree string) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	var write func(n *node)
+	write = func(n *node) {
+		atom := n.id
+		if atom == "" || strings.ContainsAny(atom, sexprReserved) {
+			atom = strconv.Quote(atom)
+		}
+		if len(n.children) == 0 {
+			b.WriteString(atom)
+			return
+		}
+		b.WriteString("(" + atom)
+		for _, c := range n.children {
+			b.WriteString(" ")
+			write(c)
+		}
+		b.WriteString(")")
+	}
+	write(root)
+	return b.String(), nil
+}
@@ -0,0 +1,36 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSExpression(t *testing.T) {
+	res, err := ParseSExpression("(a (b c (d (e f g h (i j k l)))) m n)")
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	res, _ = ParseSExpression("; menu\n(\"top level\" (\"x (1)\" y))\n")
+	assert.Equal(t, `{"top level":[{"x (1)":[{"y":[]}]}]}`, res)
+
+	res, _ = ParseSExpression("leaf")
+	assert.Equal(t, `{"leaf":[]}`, res)
+
+	_, err = ParseSExpression("(a (b c)")
+	assert.Error(t, err)
+
+	_, err = ParseSExpression("(a b) c")
+	assert.Error(t, err)
+
+	_, err = ParseSExpression("(a b b)")
+	assert.ErrorIs(t, err, ErrDuplicateId)
+}
+
+func TestFormatSExpression(t *testing.T) {
+	res, _ := FormatSExpression(testJsonTree)
+	assert.Equal(t, "(a (b c (d (e f g h (i j k l)))) m n)", res)
+
+	res, _ = FormatSExpression(`{"top level":[{"x (1)":[{"y":[]}]}]}`)
+	assert.Equal(t, `("top level" ("x (1)" y))`, res)
+}
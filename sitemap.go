@@ -0,0 +1,155 @@
+package jsontree
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const sitemapXmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapOptions controls Sitemaps.
+type SitemapOptions struct {
+	// BaseURL is prepended to every path, e.g. "https://example.com".
+	BaseURL string
+	// Slug turns an id into a path segment, url.PathEscape when nil.
+	Slug func(id string) string
+	// OmitRoot leaves the top-most ancestor out of every path, so the root
+	// page maps to BaseURL + "/".
+	OmitRoot bool
+	// Exclude leaves a node out of the sitemap. Its descendants are still
+	// listed.
+	Exclude func(info NodeInfo) bool
+	// Meta returns the optional lastmod, changefreq and priority of a node.
+	Meta func(info NodeInfo) SitemapMeta
+	// MaxURLs per sitemap document, 50000 when 0 or less.
+	MaxURLs int
+}
+
+// SitemapMeta holds the optional fields of a sitemap url. Zero values and a
+// nil Priority are left out.
+type SitemapMeta struct {
+	LastMod    time.Time
+	ChangeFreq string
+	Priority   *float64
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name            `xml:"sitemapindex"`
+	Xmlns    string              `xml:"xmlns,attr"`
+	Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+}
+
+type sitemapIndexEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Sitemaps walks the tree in document order and returns sitemap.xml
+// documents listing every page, split into several documents once MaxURLs
+// is reached. The path of a page is the slugs of the ids from the top-most
+// ancestor down to it. Use SitemapIndex to list the documents when there is
+// more than one.
+func Sitemaps(jsonTree string, opts SitemapOptions) ([]string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	slug := opts.Slug
+	if slug == nil {
+		slug = url.PathEscape
+	}
+	maxURLs := opts.MaxURLs
+	if maxURLs <= 0 {
+		maxURLs = 50000
+	}
+	base := strings.TrimSuffix(opts.BaseURL, "/")
+
+	var urls []sitemapURL
+	var visit func(n *node, info NodeInfo, path string)
+	visit = func(n *node, info NodeInfo, path string) {
+		if info.Depth > 0 || !opts.OmitRoot {
+			path += "/" + slug(n.id)
+		}
+		if opts.Exclude == nil || !opts.Exclude(info) {
+			loc := path
+			if loc == "" {
+				loc = "/"
+			}
+			u := sitemapURL{Loc: base + loc}
+			if opts.Meta != nil {
+				meta := opts.Meta(info)
+				if !meta.LastMod.IsZero() {
+					u.LastMod = meta.LastMod.Format(time.RFC3339)
+				}
+				u.ChangeFreq = meta.ChangeFreq
+				if meta.Priority != nil {
+					u.Priority = strconv.FormatFloat(*meta.Priority, 'f', -1, 64)
+					if !strings.Contains(u.Priority, ".") {
+						u.Priority += ".0"
+					}
+				}
+			}
+			urls = append(urls, u)
+		}
+		for i, c := range n.children {
+			visit(c, info.child(c, i), path)
+		}
+	}
+	visit(root, rootInfo(root), "")
+
+	var docs []string
+	for start := 0; start < len(urls) || start == 0; start += maxURLs {
+		end := start + maxURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		doc, err := marshalSitemapXml(sitemapURLSet{Xmlns: sitemapXmlns, URLs: urls[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// SitemapIndex returns a sitemap index listing the sitemap documents served
+// at locs.
+func SitemapIndex(locs []string, lastMod time.Time) (string, error) {
+	if len(locs) == 0 {
+		return "", errors.New("no sitemaps to index")
+	}
+	index := sitemapIndex{Xmlns: sitemapXmlns}
+	for _, loc := range locs {
+		entry := sitemapIndexEntry{Loc: loc}
+		if !lastMod.IsZero() {
+			entry.LastMod = lastMod.Format(time.RFC3339)
+		}
+		index.Sitemaps = append(index.Sitemaps, entry)
+	}
+	return marshalSitemapXml(index)
+}
+
+func marshalSitemapXml(v interface{}) (string, error) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(out) + "\n", nil
+}
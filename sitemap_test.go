@@ -0,0 +1,67 @@
+package jsontree
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSitemaps(t *testing.T) {
+	one, quarter, zero := 1.0, 0.25, 0.0
+	docs, _ := Sitemaps(`{"home":[{"products":[{"Running Shoes":[]}]},{"about":[]}]}`, SitemapOptions{
+		BaseURL:  "https://example.com/",
+		OmitRoot: true,
+		Slug:     func(id string) string { return strings.ToLower(strings.ReplaceAll(id, " ", "-")) },
+		Exclude:  func(info NodeInfo) bool { return info.Id == "products" },
+		Meta: func(info NodeInfo) SitemapMeta {
+			if info.Depth == 0 {
+				return SitemapMeta{LastMod: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ChangeFreq: "daily", Priority: &one}
+			}
+			if info.Id == "about" {
+				return SitemapMeta{Priority: &zero}
+			}
+			return SitemapMeta{Priority: &quarter}
+		},
+	})
+	assert.Equal(t, 1, len(docs))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/</loc>
+    <lastmod>2024-01-02T03:04:05Z</lastmod>
+    <changefreq>daily</changefreq>
+    <priority>1.0</priority>
+  </url>
+  <url>
+    <loc>https://example.com/products/running-shoes</loc>
+    <priority>0.25</priority>
+  </url>
+  <url>
+    <loc>https://example.com/about</loc>
+    <priority>0.0</priority>
+  </url>
+</urlset>
+`, docs[0])
+}
+
+func TestSitemapsSplit(t *testing.T) {
+	docs, _ := Sitemaps(testJsonTree, SitemapOptions{BaseURL: "https://example.com", MaxURLs: 5})
+	assert.Equal(t, 3, len(docs))
+	assert.Contains(t, docs[0], "<loc>https://example.com/a/b/d/e</loc>")
+	assert.Contains(t, docs[2], "<loc>https://example.com/a/n</loc>")
+	assert.Equal(t, 4, strings.Count(docs[2], "<url>"))
+
+	index, _ := SitemapIndex([]string{"https://example.com/sitemap-1.xml", "https://example.com/sitemap-2.xml"}, time.Time{})
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap>
+    <loc>https://example.com/sitemap-1.xml</loc>
+  </sitemap>
+  <sitemap>
+    <loc>https://example.com/sitemap-2.xml</loc>
+  </sitemap>
+</sitemapindex>
+`, index)
+}
@@ -0,0 +1,141 @@
+package jsontree
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Decode maps a json tree onto a struct hierarchy such as
+//
+//	type Category struct {
+//		ID       string     `jsontree:"id"`
+//		Children []Category `jsontree:"children"`
+//		Title    string
+//	}
+//
+// T must be a struct, or a pointer to one, with a string field tagged
+// jsontree:"id" and a slice field tagged jsontree:"children" holding T or
+// *T. Without tags, fields named ID or Id and Children are used. Other
+// fields are left at their zero value.
+func Decode[T any](jsonTree string) (T, error) {
+	var out T
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return out, err
+	}
+	v := reflect.ValueOf(&out).Elem()
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	fields, err := treeFieldsOf(v.Type())
+	if err != nil {
+		return out, err
+	}
+	decodeStruct(root, v, fields)
+	return out, nil
+}
+
+// Encode is the inverse of Decode, building a json tree from the id and
+// children fields of v. Nil children are skipped.
+func Encode[T any](v T) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "", errors.New("cannot encode a nil tree")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return "", errors.New("Encode needs a struct, got " + rv.Kind().String())
+	}
+	fields, err := treeFieldsOf(rv.Type())
+	if err != nil {
+		return "", err
+	}
+	return encodeStruct(rv, fields).String(), nil
+}
+
+type treeFields struct {
+	id       int
+	children int
+	// pointer is set when children are held as []*T.
+	pointer bool
+}
+
+func treeFieldsOf(t reflect.Type) (treeFields, error) {
+	fields := treeFields{id: -1, children: -1}
+	if t.Kind() != reflect.Struct {
+		return fields, errors.New("expected a struct, got " + t.Kind().String())
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("jsontree"), ",")[0]
+		if (tag == "id" || tag == "children") && !f.IsExported() {
+			return fields, errors.New(t.String() + " field " + f.Name + " is tagged " + tag + " but unexported")
+		}
+		switch tag {
+		case "id":
+			fields.id = i
+		case "children":
+			fields.children = i
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		switch name := t.Field(i).Name; {
+		case fields.id < 0 && (name == "ID" || name == "Id"):
+			fields.id = i
+		case fields.children < 0 && name == "Children":
+			fields.children = i
+		}
+	}
+	if fields.id < 0 || t.Field(fields.id).Type.Kind() != reflect.String {
+		return fields, errors.New(t.String() + " has no string id field")
+	}
+	if fields.children < 0 {
+		return fields, errors.New(t.String() + " has no children field")
+	}
+	children := t.Field(fields.children).Type
+	switch {
+	case children.Kind() == reflect.Slice && children.Elem() == t:
+	case children.Kind() == reflect.Slice && children.Elem().Kind() == reflect.Ptr && children.Elem().Elem() == t:
+		fields.pointer = true
+	default:
+		return fields, errors.New("children of " + t.Name() + " must be []" + t.Name() + " or []*" + t.Name())
+	}
+	return fields, nil
+}
+
+func decodeStruct(n *node, v reflect.Value, fields treeFields) {
+	v.Field(fields.id).SetString(n.id)
+	if len(n.children) == 0 {
+		return
+	}
+	children := v.Field(fields.children)
+	children.Set(reflect.MakeSlice(children.Type(), len(n.children), len(n.children)))
+	for i, c := range n.children {
+		elem := children.Index(i)
+		if fields.pointer {
+			elem.Set(reflect.New(v.Type()))
+			elem = elem.Elem()
+		}
+		decodeStruct(c, elem, fields)
+	}
+}
+
+func encodeStruct(v reflect.Value, fields treeFields) *node {
+	n := &node{id: v.Field(fields.id).String()}
+	children := v.Field(fields.children)
+	for i := 0; i < children.Len(); i++ {
+		elem := children.Index(i)
+		if fields.pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		n.children = append(n.children, encodeStruct(elem, fields))
+	}
+	return n
+}
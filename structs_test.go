@@ -0,0 +1,63 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCategory struct {
+	Slug     string         `jsontree:"id"`
+	Items    []testCategory `jsontree:"children"`
+	Title    string
+	Disabled bool
+}
+
+type testPointerCategory struct {
+	ID       string
+	Children []*testPointerCategory
+}
+
+type testUnexportedCategory struct {
+	id       string `jsontree:"id"`
+	Children []testUnexportedCategory
+}
+
+func TestDecode(t *testing.T) {
+	res, err := Decode[testCategory](`{"a":[{"b":[{"c":[]}]},{"m":[]}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, testCategory{Slug: "a", Items: []testCategory{
+		{Slug: "b", Items: []testCategory{{Slug: "c"}}},
+		{Slug: "m"},
+	}}, res)
+
+	ptr, err := Decode[*testPointerCategory](testJsonTreeSimple)
+	assert.NoError(t, err)
+	assert.Equal(t, &testPointerCategory{ID: "a", Children: []*testPointerCategory{{ID: "b"}}}, ptr)
+
+	_, err = Decode[struct{ Name string }](testJsonTreeSimple)
+	assert.Error(t, err)
+
+	_, err = Decode[string](testJsonTreeSimple)
+	assert.Error(t, err)
+
+	_, err = Decode[testUnexportedCategory](testJsonTreeSimple)
+	assert.Error(t, err)
+	_, err = Encode(testUnexportedCategory{id: "a"})
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	res, _ := Encode(testCategory{Slug: "a", Title: "A", Items: []testCategory{{Slug: "b"}, {Slug: "m"}}})
+	assert.Equal(t, `{"a":[{"b":[]},{"m":[]}]}`, res)
+
+	tree, _ := Decode[*testPointerCategory](testJsonTree)
+	res, _ = Encode(tree)
+	assert.Equal(t, testJsonTree, res)
+
+	res, _ = Encode(&testPointerCategory{ID: "a", Children: []*testPointerCategory{nil, {ID: "b"}}})
+	assert.Equal(t, `{"a":[{"b":[]}]}`, res)
+
+	_, err := Encode((*testPointerCategory)(nil))
+	assert.Error(t, err)
+}
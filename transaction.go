@@ -0,0 +1,83 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Transaction queues mutations against a tree and applies them all at once
+// on Commit. The batch is applied to an in-memory copy of the tree which is
+// serialized a single time, and nothing is returned unless every queued
+// operation succeeds.
+type Transaction struct {
+	tree string
+	ops  []operation
+	done bool
+}
+
+func Begin(jsonTree string) *Transaction {
+	return &Transaction{tree: jsonTree}
+}
+
+func (t *Transaction) AddNextToLeafById(id string, insertBranch string, beforeAfter string) {
+	t.ops = append(t.ops, operation{kind: opAddNextTo, id: id, branch: insertBranch, directive: beforeAfter})
+}
+
+func (t *Transaction) AddIntoLeafById(id string, insertBranch string, topBottom string) {
+	t.ops = append(t.ops, operation{kind: opAddInto, id: id, branch: insertBranch, directive: topBottom})
+}
+
+func (t *Transaction) RemoveById(id string) {
+	t.ops = append(t.ops, operation{kind: opRemove, id: id})
+}
+
+// Len returns the number of queued operations.
+func (t *Transaction) Len() int {
+	return len(t.ops)
+}
+
+// Validate checks the whole batch against the tree without returning a
+// result. The error names the first operation that would fail.
+func (t *Transaction) Validate() error {
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+	_, err := t.run()
+	return err
+}
+
+func (t *Transaction) Commit() (string, error) {
+	if t.done {
+		return t.tree, errors.New("transaction already finished")
+	}
+	newTree, err := t.run()
+	if err != nil {
+		return t.tree, err
+	}
+	t.done = true
+	return newTree, nil
+}
+
+// Rollback discards the queued operations and returns the untouched tree.
+func (t *Transaction) Rollback() string {
+	t.ops = nil
+	t.done = true
+	return t.tree
+}
+
+func (t *Transaction) run() (string, error) {
+	if len(t.ops) == 0 {
+		return t.tree, nil
+	}
+	root, err := parseTree(t.tree)
+	if err != nil {
+		return "", err
+	}
+	known := knownIds(root)
+	for i, o := range t.ops {
+		if err := o.applyNode(root, known); err != nil {
+			return "", fmt.Errorf("operation %d (%s %s): %w", i, o.kind, o.id, err)
+		}
+	}
+	return root.String(), nil
+}
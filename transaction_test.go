@@ -0,0 +1,62 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionCommit(t *testing.T) {
+	tx := Begin(testJsonTree)
+	tx.AddNextToLeafById(`h`, `{"w": [{"y":[]}]}`, "before")
+	tx.AddIntoLeafById(`b`, `{"xxx":[]}`, "insideEnd")
+	tx.RemoveById(`i`)
+	tx.AddIntoLeafById(`n`, `{"z":[]}`, "insideBeginning")
+	assert.Equal(t, 4, tx.Len())
+
+	res, err := tx.Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"w":[{"y":[]}]},{"h":[]}]}]},{"xxx":[]}]},{"m":[]},{"n":[{"z":[]}]}]}`, res)
+
+	_, err = tx.Commit()
+	assert.Error(t, err)
+	assert.Error(t, tx.Validate())
+}
+
+func TestTransactionMatchesSingleMutations(t *testing.T) {
+	expected, _ := AddNextToLeafById(testJsonTree, `l`, `{"w": [{"y":[]}]}`, "after")
+	expected, _ = RemoveById(expected, `c`)
+
+	tx := Begin(testJsonTree)
+	tx.AddNextToLeafById(`l`, `{"w": [{"y":[]}]}`, "after")
+	tx.RemoveById(`c`)
+	res, _ := tx.Commit()
+	assert.Equal(t, expected, res)
+}
+
+func TestTransactionValidation(t *testing.T) {
+	tx := Begin(testJsonTree)
+	tx.RemoveById(`i`)
+	tx.AddIntoLeafById(`j`, `{"w":[]}`, "insideEnd")
+	err := tx.Validate()
+	assert.Error(t, err)
+
+	res, err := tx.Commit()
+	assert.Error(t, err)
+	assert.Equal(t, testJsonTree, res)
+
+	tx = Begin(testJsonTree)
+	tx.AddNextToLeafById(`c`, `{"m":[]}`, "after")
+	assert.Error(t, tx.Validate())
+
+	tx = Begin(testJsonTree)
+	tx.RemoveById(`a`)
+	assert.Error(t, tx.Validate())
+
+	tx = Begin(testJsonTree)
+	tx.RemoveById(`b`)
+	assert.Equal(t, testJsonTree, tx.Rollback())
+	_, err = tx.Commit()
+	assert.Error(t, err)
+	assert.Error(t, tx.Validate())
+}
@@ -0,0 +1,311 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Tree is an in-memory tree where every node holds an id and a payload of
+// type T. Its methods answer the same queries and make the same changes as
+// the package functions of the same name, without going through json.
+type Tree[T any] struct {
+	root  *treeNode[T]
+	index map[string]*treeNode[T]
+}
+
+type treeNode[T any] struct {
+	id       string
+	payload  T
+	parent   *treeNode[T]
+	children []*treeNode[T]
+}
+
+var errNoId = errors.New("no id/path found")
+
+// NewTree returns a tree holding a single node.
+func NewTree[T any](id string, payload T) *Tree[T] {
+	root := &treeNode[T]{id: id, payload: payload}
+	return &Tree[T]{root: root, index: map[string]*treeNode[T]{id: root}}
+}
+
+// TreeFromJson builds a Tree from a json tree, every payload being the zero
+// value of T.
+func TreeFromJson[T any](jsonTree string) (*Tree[T], error) {
+	n, err := parseTree(jsonTree)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tree[T]{index: make(map[string]*treeNode[T])}
+	var build func(n *node, parent *treeNode[T]) (*treeNode[T], error)
+	build = func(n *node, parent *treeNode[T]) (*treeNode[T], error) {
+		if _, ok := t.index[n.id]; ok {
+			return nil, fmt.Errorf("id %s: %w", n.id, ErrDuplicateId)
+		}
+		tn := &treeNode[T]{id: n.id, parent: parent}
+		t.index[n.id] = tn
+		for _, c := range n.children {
+			child, err := build(c, tn)
+			if err != nil {
+				return nil, err
+			}
+			tn.children = append(tn.children, child)
+		}
+		return tn, nil
+	}
+	t.root, err = build(n, nil)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Json returns the tree in the {"id":[children]} format, dropping payloads.
+func (t *Tree[T]) Json() string {
+	var convert func(tn *treeNode[T]) *node
+	convert = func(tn *treeNode[T]) *node {
+		n := &node{id: tn.id}
+		for _, c := range tn.children {
+			n.children = append(n.children, convert(c))
+		}
+		return n
+	}
+	return convert(t.root).String()
+}
+
+func (t *Tree[T]) Has(id string) bool {
+	_, ok := t.index[id]
+	return ok
+}
+
+func (t *Tree[T]) Get(id string) (T, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		var zero T
+		return zero, errNoId
+	}
+	return tn.payload, nil
+}
+
+func (t *Tree[T]) Set(id string, payload T) error {
+	tn, ok := t.index[id]
+	if !ok {
+		return errNoId
+	}
+	tn.payload = payload
+	return nil
+}
+
+func (t *Tree[T]) GetTopmostAncestorId() string {
+	return t.root.id
+}
+
+func (t *Tree[T]) GetParentId(id string) (string, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return "", errNoId
+	}
+	if tn.parent == nil {
+		return "", nil
+	}
+	return tn.parent.id, nil
+}
+
+func (t *Tree[T]) GetDescendantsIds(id string, childrenOnly bool) ([]string, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return nil, errNoId
+	}
+	var ids []string
+	var visit func(tn *treeNode[T])
+	visit = func(tn *treeNode[T]) {
+		for _, c := range tn.children {
+			ids = append(ids, c.id)
+			if !childrenOnly {
+				visit(c)
+			}
+		}
+	}
+	visit(tn)
+	return ids, nil
+}
+
+func (t *Tree[T]) GetAllSiblingsIds(id string) ([]string, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return nil, errNoId
+	}
+	if tn.parent == nil {
+		return nil, nil
+	}
+	var ids []string
+	for _, c := range tn.parent.children {
+		if c != tn {
+			ids = append(ids, c.id)
+		}
+	}
+	return ids, nil
+}
+
+func (t *Tree[T]) GetYoungerSiblingsIds(id string) ([]string, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return nil, errNoId
+	}
+	if tn.parent == nil {
+		return nil, nil
+	}
+	var ids []string
+	for _, c := range tn.parent.children[tn.siblingIndex()+1:] {
+		ids = append(ids, c.id)
+	}
+	return ids, nil
+}
+
+func (t *Tree[T]) GetNextYoungerSiblingId(id string) (string, error) {
+	ids, err := t.GetYoungerSiblingsIds(id)
+	if err != nil || len(ids) == 0 {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+func (t *Tree[T]) GetElderSiblingId(id string) (string, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return "", errNoId
+	}
+	if tn.parent == nil || tn.siblingIndex() == 0 {
+		return "", nil
+	}
+	return tn.parent.children[tn.siblingIndex()-1].id, nil
+}
+
+func (t *Tree[T]) GetFirstChildId(id string) (string, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return "", errNoId
+	}
+	if len(tn.children) == 0 {
+		return "", errors.New("No children found.")
+	}
+	return tn.children[0].id, nil
+}
+
+func (t *Tree[T]) HasChildren(id string) (bool, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return false, errNoId
+	}
+	return len(tn.children) > 0, nil
+}
+
+func (t *Tree[T]) IsFirstChild(id string) (bool, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return false, errNoId
+	}
+	return tn.siblingIndex() == 0, nil
+}
+
+func (t *Tree[T]) IsLastChild(id string) (bool, error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return false, errNoId
+	}
+	return tn.parent == nil || tn.siblingIndex() == len(tn.parent.children)-1, nil
+}
+
+// AddNextToLeafById puts branch "before" or "after" id. The nodes of branch
+// become part of t, so branch must not be used afterwards.
+func (t *Tree[T]) AddNextToLeafById(id string, branch *Tree[T], beforeAfter string) error {
+	tn, ok := t.index[id]
+	if !ok {
+		return errNoId
+	}
+	if tn.parent == nil {
+		return errors.New("cannot add next to top-most ancestor")
+	}
+	index := tn.siblingIndex()
+	switch beforeAfter {
+	case "before":
+	case "after":
+		index++
+	default:
+		return errors.New("directive must be either before or after")
+	}
+	return t.attach(tn.parent, index, branch)
+}
+
+// AddIntoLeafById puts branch first ("insideBeginning") or last
+// ("insideEnd") among the children of id. The nodes of branch become part
+// of t, so branch must not be used afterwards.
+func (t *Tree[T]) AddIntoLeafById(id string, branch *Tree[T], topBottom string) error {
+	tn, ok := t.index[id]
+	if !ok {
+		return errNoId
+	}
+	switch topBottom {
+	case "insideBeginning":
+		return t.attach(tn, 0, branch)
+	case "insideEnd":
+		return t.attach(tn, len(tn.children), branch)
+	}
+	return errors.New("directive must be either insideBeginning or insideEnd")
+}
+
+// RemoveById detaches id and its descendants, returning them as a tree of
+// their own.
+func (t *Tree[T]) RemoveById(id string) (*Tree[T], error) {
+	tn, ok := t.index[id]
+	if !ok {
+		return nil, errNoId
+	}
+	if tn.parent == nil {
+		return nil, errors.New("cannot remove top-most ancestor")
+	}
+	parent := tn.parent
+	index := tn.siblingIndex()
+	parent.children = append(parent.children[:index:index], parent.children[index+1:]...)
+	tn.parent = nil
+	removed := &Tree[T]{root: tn, index: make(map[string]*treeNode[T])}
+	tn.walk(func(c *treeNode[T]) {
+		delete(t.index, c.id)
+		removed.index[c.id] = c
+	})
+	return removed, nil
+}
+
+func (t *Tree[T]) attach(parent *treeNode[T], index int, branch *Tree[T]) error {
+	for id := range branch.index {
+		if _, ok := t.index[id]; ok {
+			return errors.New("id " + id + " already exists in tree")
+		}
+	}
+	for id, tn := range branch.index {
+		t.index[id] = tn
+	}
+	branch.root.parent = parent
+	parent.children = append(parent.children, nil)
+	copy(parent.children[index+1:], parent.children[index:])
+	parent.children[index] = branch.root
+	return nil
+}
+
+func (tn *treeNode[T]) siblingIndex() int {
+	if tn.parent == nil {
+		return 0
+	}
+	for i, c := range tn.parent.children {
+		if c == tn {
+			return i
+		}
+	}
+	return -1
+}
+
+func (tn *treeNode[T]) walk(fn func(c *treeNode[T])) {
+	fn(tn)
+	for _, c := range tn.children {
+		c.walk(fn)
+	}
+}
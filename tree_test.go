@@ -0,0 +1,88 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeQueriesMatchJson(t *testing.T) {
+	tree, err := TreeFromJson[int](testJsonTree)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, tree.Json())
+	assert.Equal(t, "a", tree.GetTopmostAncestorId())
+
+	ids, _ := GetDescendantsIds(testJsonTree, "a", false)
+	for _, id := range append(ids, "a") {
+		expectedString, _ := GetParentId(testJsonTree, id)
+		actualString, _ := tree.GetParentId(id)
+		assert.Equal(t, expectedString, actualString, id)
+
+		expectedString, _ = GetNextYoungerSiblingId(testJsonTree, id)
+		actualString, _ = tree.GetNextYoungerSiblingId(id)
+		assert.Equal(t, expectedString, actualString, id)
+
+		expectedString, _ = GetElderSiblingId(testJsonTree, id)
+		actualString, _ = tree.GetElderSiblingId(id)
+		assert.Equal(t, expectedString, actualString, id)
+
+		expectedString, _ = GetFirstChildId(testJsonTree, id)
+		actualString, _ = tree.GetFirstChildId(id)
+		assert.Equal(t, expectedString, actualString, id)
+
+		expectedIds, _ := GetAllSiblingsIds(testJsonTree, id)
+		actualIds, _ := tree.GetAllSiblingsIds(id)
+		assert.Equal(t, expectedIds, actualIds, id)
+
+		expectedIds, _ = GetYoungerSiblingsIds(testJsonTree, id)
+		actualIds, _ = tree.GetYoungerSiblingsIds(id)
+		assert.Equal(t, expectedIds, actualIds, id)
+
+		expectedIds, _ = GetDescendantsIds(testJsonTree, id, false)
+		actualIds, _ = tree.GetDescendantsIds(id, false)
+		assert.Equal(t, expectedIds, actualIds, id)
+
+		expectedBool, _ := HasChildren(testJsonTree, id)
+		actualBool, _ := tree.HasChildren(id)
+		assert.Equal(t, expectedBool, actualBool, id)
+
+		expectedBool, _ = IsFirstChild(testJsonTree, id)
+		actualBool, _ = tree.IsFirstChild(id)
+		assert.Equal(t, expectedBool, actualBool, id)
+
+		expectedBool, _ = IsLastChild(testJsonTree, id)
+		actualBool, _ = tree.IsLastChild(id)
+		assert.Equal(t, expectedBool, actualBool, id)
+	}
+
+	_, err = tree.GetParentId("zz")
+	assert.Error(t, err)
+}
+
+func TestTreeMutationsMatchJson(t *testing.T) {
+	tree, _ := TreeFromJson[string](testJsonTree)
+	branch, _ := TreeFromJson[string](`{"w": [{"y":[]}]}`)
+	branch.Set("w", "payload of w")
+
+	assert.NoError(t, tree.AddNextToLeafById("h", branch, "before"))
+	expected, _ := AddNextToLeafById(testJsonTree, `h`, `{"w": [{"y":[]}]}`, "before")
+	assert.Equal(t, expected, tree.Json())
+	payload, _ := tree.Get("w")
+	assert.Equal(t, "payload of w", payload)
+
+	assert.NoError(t, tree.AddIntoLeafById("b", NewTree("xxx", "x"), "insideEnd"))
+	expected, _ = AddIntoLeafById(expected, `b`, `{"xxx":[]}`, "insideEnd")
+	assert.Equal(t, expected, tree.Json())
+
+	removed, err := tree.RemoveById("w")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"w":[{"y":[]}]}`, removed.Json())
+	expected, _ = RemoveById(expected, `w`)
+	assert.Equal(t, expected, tree.Json())
+	assert.False(t, tree.Has("y"))
+
+	assert.Error(t, tree.AddIntoLeafById("c", NewTree("d", ""), "insideEnd"))
+	_, err = tree.RemoveById("a")
+	assert.Error(t, err)
+	assert.Error(t, tree.AddNextToLeafById("a", NewTree("z", ""), "after"))
+}
@@ -0,0 +1,81 @@
+package jsontree
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// JsonTree is a json tree as a typed value. It embeds as a json object in
+// other json documents, as text in encodings using encoding.TextMarshaler,
+// and as a json column through database/sql. Sibling order is kept in every
+// direction. Use string(t) with the functions of the package.
+//
+// The empty JsonTree stands for no tree and becomes null.
+type JsonTree string
+
+func (t JsonTree) MarshalJSON() ([]byte, error) {
+	if t == "" {
+		return []byte("null"), nil
+	}
+	root, err := parseTree(string(t))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(root.String()), nil
+}
+
+// UnmarshalJSON accepts a tree object or null, storing the tree compacted.
+func (t *JsonTree) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = ""
+		return nil
+	}
+	root, err := parseTree(string(data))
+	if err != nil {
+		return err
+	}
+	*t = JsonTree(root.String())
+	return nil
+}
+
+func (t JsonTree) MarshalText() ([]byte, error) {
+	if t == "" {
+		return []byte{}, nil
+	}
+	return t.MarshalJSON()
+}
+
+func (t *JsonTree) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = ""
+		return nil
+	}
+	return t.UnmarshalJSON(text)
+}
+
+// Scan reads a tree from a json, jsonb or text column. NULL gives the
+// empty JsonTree.
+func (t *JsonTree) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = ""
+		return nil
+	case []byte:
+		return t.UnmarshalText(v)
+	case string:
+		return t.UnmarshalText([]byte(v))
+	}
+	return fmt.Errorf("cannot scan %T into JsonTree", src)
+}
+
+// Value writes the tree as a json string, or NULL for the empty JsonTree.
+func (t JsonTree) Value() (driver.Value, error) {
+	if t == "" {
+		return nil, nil
+	}
+	data, err := t.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
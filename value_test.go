@@ -0,0 +1,54 @@
+package jsontree
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMenuRecord struct {
+	Name string   `json:"name"`
+	Tree JsonTree `json:"tree"`
+}
+
+func TestJsonTreeJson(t *testing.T) {
+	out, err := json.Marshal(testMenuRecord{Name: "main", Tree: JsonTree(testJsonTree)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"main","tree":`+testJsonTree+`}`, string(out))
+
+	var record testMenuRecord
+	err = json.Unmarshal([]byte(`{"name":"main","tree":{"a": [ {"n":[]}, {"m":[]} ]}}`), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, JsonTree(`{"a":[{"n":[]},{"m":[]}]}`), record.Tree)
+	id, _ := GetNextYoungerSiblingId(string(record.Tree), "n")
+	assert.Equal(t, "m", id)
+
+	out, _ = json.Marshal(testMenuRecord{})
+	assert.Equal(t, `{"name":"","tree":null}`, string(out))
+
+	err = json.Unmarshal([]byte(`{"tree":[1,2]}`), &record)
+	assert.Error(t, err)
+}
+
+func TestJsonTreeText(t *testing.T) {
+	var tree JsonTree
+	assert.NoError(t, tree.UnmarshalText([]byte(testJsonTreeSimple)))
+	text, _ := tree.MarshalText()
+	assert.Equal(t, `{"a":[{"b":[]}]}`, string(text))
+}
+
+func TestJsonTreeSql(t *testing.T) {
+	var tree JsonTree
+	assert.NoError(t, tree.Scan([]byte(testJsonTree)))
+	assert.Equal(t, JsonTree(testJsonTree), tree)
+
+	value, _ := tree.Value()
+	assert.Equal(t, testJsonTree, value)
+
+	assert.NoError(t, tree.Scan(nil))
+	value, _ = tree.Value()
+	assert.Nil(t, value)
+
+	assert.Error(t, tree.Scan(42))
+}
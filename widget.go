@@ -0,0 +1,150 @@
+package jsontree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gjson "github.com/tidwall/gjson"
+)
+
+// WidgetFields names the object fields of a nested widget tree, such as
+// Ant Design's {"key","title","children"} or react-arborist's
+// {"id","name","children"}.
+type WidgetFields struct {
+	Key      string
+	Title    string
+	Children string
+}
+
+var AntDesignFields = WidgetFields{Key: "key", Title: "title", Children: "children"}
+var ArboristFields = WidgetFields{Key: "id", Title: "name", Children: "children"}
+
+// jsTreeRoot is the parent value jsTree uses for top level nodes.
+const jsTreeRoot = "#"
+
+// ToJsTree returns the flat jsTree format
+// [{"id":"a","parent":"#","text":"a"}, ...] in document order, so the
+// children of any node appear in the order GetDescendantsIds returns them.
+func ToJsTree(jsonTree string) (string, error) {
+	rows, err := ToAdjacencyList(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		parent := row.ParentId
+		if parent == "" {
+			parent = jsTreeRoot
+		}
+		b.WriteString(`{"id":` + quoteJson(row.Id) + `,"parent":` + quoteJson(parent) + `,"text":` + quoteJson(row.Id) + `}`)
+	}
+	b.WriteString("]")
+	return b.String(), nil
+}
+
+// FromJsTree builds a json tree from the flat jsTree format. Siblings keep
+// their order in the array and the text field is ignored.
+func FromJsTree(jsTree string) (string, error) {
+	if !gjson.Valid(jsTree) {
+		return "", errors.New("invalid jstree json")
+	}
+	var rows []AdjacencyRow
+	var err error
+	gjson.Parse(jsTree).ForEach(func(_, value gjson.Result) bool {
+		id := value.Get("id")
+		if !id.Exists() {
+			err = errors.New("jstree node without an id")
+			return false
+		}
+		parent := value.Get("parent").String()
+		if parent == jsTreeRoot {
+			parent = ""
+		}
+		rows = append(rows, AdjacencyRow{Id: id.String(), ParentId: parent, Position: len(rows)})
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	return FromAdjacencyList(rows)
+}
+
+// ToNestedWidget returns the tree as a single element array of nested
+// widget objects named by fields, with the id as both key and title.
+func ToNestedWidget(jsonTree string, fields WidgetFields) (string, error) {
+	root, err := parseTree(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("[")
+	writeWidgetNode(&b, root, fields)
+	b.WriteString("]")
+	return b.String(), nil
+}
+
+func writeWidgetNode(b *strings.Builder, n *node, fields WidgetFields) {
+	b.WriteString("{" + quoteJson(fields.Key) + ":" + quoteJson(n.id))
+	if fields.Title != "" {
+		b.WriteString("," + quoteJson(fields.Title) + ":" + quoteJson(n.id))
+	}
+	b.WriteString("," + quoteJson(fields.Children) + ":[")
+	for i, c := range n.children {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		writeWidgetNode(b, c, fields)
+	}
+	b.WriteString("]}")
+}
+
+// FromNestedWidget builds a json tree from nested widget objects named by
+// fields. The input may be a single object or an array holding one root.
+func FromNestedWidget(widgetTree string, fields WidgetFields) (string, error) {
+	if !gjson.Valid(widgetTree) {
+		return "", errors.New("invalid widget json")
+	}
+	value := gjson.Parse(widgetTree)
+	if value.IsArray() {
+		roots := value.Array()
+		if len(roots) != 1 {
+			return "", errors.New("widget tree must have exactly one root")
+		}
+		value = roots[0]
+	}
+	root, err := parseWidgetNode(value, fields, make(map[string]bool))
+	if err != nil {
+		return "", err
+	}
+	return root.String(), nil
+}
+
+func parseWidgetNode(value gjson.Result, fields WidgetFields, seen map[string]bool) (*node, error) {
+	object := value.Map()
+	key, ok := object[fields.Key]
+	if !value.IsObject() || !ok {
+		return nil, errors.New("widget node without a " + fields.Key + " field")
+	}
+	n := &node{id: key.String()}
+	if seen[n.id] {
+		return nil, fmt.Errorf("id %s: %w", n.id, ErrDuplicateId)
+	}
+	seen[n.id] = true
+	children := object[fields.Children]
+	if children.Exists() && children.Type != gjson.Null && !children.IsArray() {
+		return nil, errors.New(fields.Children + " of " + n.id + " must be an array")
+	}
+	for _, child := range children.Array() {
+		c, err := parseWidgetNode(child, fields, seen)
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, c)
+	}
+	return n, nil
+}
@@ -0,0 +1,50 @@
+package jsontree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsTree(t *testing.T) {
+	res, _ := ToJsTree(testJsonTreeSimple)
+	assert.Equal(t, `[{"id":"a","parent":"#","text":"a"},{"id":"b","parent":"a","text":"b"}]`, res)
+
+	res, _ = ToJsTree(testJsonTree)
+	back, err := FromJsTree(res)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+
+	_, err = FromJsTree(`[{"id":"a","parent":"#"},{"id":"b","parent":"x"}]`)
+	assert.Error(t, err)
+}
+
+func TestNestedWidget(t *testing.T) {
+	res, _ := ToNestedWidget(testJsonTreeSimple, AntDesignFields)
+	assert.Equal(t, `[{"key":"a","title":"a","children":[{"key":"b","title":"b","children":[]}]}]`, res)
+
+	res, _ = ToNestedWidget(testJsonTreeSimple, ArboristFields)
+	assert.Equal(t, `[{"id":"a","name":"a","children":[{"id":"b","name":"b","children":[]}]}]`, res)
+
+	res, _ = ToNestedWidget(testJsonTree, AntDesignFields)
+	back, err := FromNestedWidget(res, AntDesignFields)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+
+	back, _ = FromNestedWidget(`{"key":"a","children":[{"key":"c"},{"key":"b","title":"B"}]}`, AntDesignFields)
+	assert.Equal(t, `{"a":[{"c":[]},{"b":[]}]}`, back)
+	ids, _ := GetDescendantsIds(back, "a", true)
+	assert.Equal(t, []string{"c", "b"}, ids)
+
+	_, err = FromNestedWidget(`[{"key":"a"},{"key":"b"}]`, AntDesignFields)
+	assert.Error(t, err)
+
+	_, err = FromNestedWidget(`{"key":"a","children":[{"key":"a"}]}`, AntDesignFields)
+	assert.ErrorIs(t, err, ErrDuplicateId)
+
+	_, err = FromNestedWidget(`{"key":"a","children":{"key":"b"}}`, AntDesignFields)
+	assert.Error(t, err)
+
+	back, _ = FromNestedWidget(`{"key":"a","children":null}`, AntDesignFields)
+	assert.Equal(t, `{"a":[]}`, back)
+}
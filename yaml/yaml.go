@@ -0,0 +1,135 @@
+// Package yaml converts json trees to and from their YAML form, a mapping of
+// the id to a sequence of single-key mappings:
+//
+//	a:
+//	  - b: []
+//	  - m: []
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bmilesp/jsontree"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Unmarshal converts the YAML form of a tree into the json tree the jsontree
+// package works on.
+func Unmarshal(yamlTree string) (string, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(yamlTree), &doc); err != nil {
+		return "", err
+	}
+	if len(doc.Content) == 0 {
+		return "", errors.New("empty yaml document")
+	}
+	v, err := valueFromYAML(doc.Content[0])
+	if err != nil {
+		return "", err
+	}
+	return jsontree.FromValue(v)
+}
+
+func valueFromYAML(y *yamlv3.Node) (map[string]interface{}, error) {
+	if y.Kind != yamlv3.MappingNode || len(y.Content) != 2 {
+		return nil, fmt.Errorf("line %d: expected a mapping with a single id", y.Line)
+	}
+	key, value := y.Content[0], y.Content[1]
+	children := []interface{}{}
+	if value.Kind == yamlv3.ScalarNode && value.Tag == "!!null" {
+		return map[string]interface{}{key.Value: children}, nil
+	}
+	if value.Kind != yamlv3.SequenceNode {
+		return nil, fmt.Errorf("line %d: children of %s must be a sequence", value.Line, key.Value)
+	}
+	for _, child := range value.Content {
+		c, err := valueFromYAML(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, c)
+	}
+	return map[string]interface{}{key.Value: children}, nil
+}
+
+// Marshal converts a json tree into its YAML form.
+func Marshal(jsonTree string) (string, error) {
+	return Update("", jsonTree)
+}
+
+// Update writes jsonTree in YAML form, carrying over the comments that
+// yamlTree attached to ids still present in jsonTree. It is meant for
+// writing back a YAML file after changing the tree read from it with
+// Unmarshal.
+func Update(yamlTree string, jsonTree string) (string, error) {
+	root, err := jsontree.ToValue(jsonTree)
+	if err != nil {
+		return "", err
+	}
+	comments := make(map[string][3]*yamlv3.Node)
+	var doc yamlv3.Node
+	if yamlTree != "" {
+		if err := yamlv3.Unmarshal([]byte(yamlTree), &doc); err != nil {
+			return "", err
+		}
+		if len(doc.Content) > 0 {
+			collectComments(doc.Content[0], comments)
+		}
+	}
+	out := &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{yamlFromValue(root, comments)}}
+	if len(doc.Content) > 0 {
+		out.HeadComment = doc.HeadComment
+		out.FootComment = doc.FootComment
+	}
+	var b strings.Builder
+	enc := yamlv3.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(out); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// collectComments records the mapping, key and value node of every id so
+// their comments can be copied onto a rebuilt document.
+func collectComments(y *yamlv3.Node, comments map[string][3]*yamlv3.Node) {
+	if y.Kind != yamlv3.MappingNode || len(y.Content) != 2 {
+		return
+	}
+	key, value := y.Content[0], y.Content[1]
+	comments[key.Value] = [3]*yamlv3.Node{y, key, value}
+	for _, child := range value.Content {
+		collectComments(child, comments)
+	}
+}
+
+// yamlFromValue converts a tree in the form returned by jsontree.ToValue.
+func yamlFromValue(v map[string]interface{}, comments map[string][3]*yamlv3.Node) *yamlv3.Node {
+	var id string
+	var children []interface{}
+	for k, c := range v {
+		id, children = k, c.([]interface{})
+	}
+	key := &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: id}
+	value := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+	if len(children) == 0 {
+		value.Style = yamlv3.FlowStyle
+	}
+	for _, c := range children {
+		value.Content = append(value.Content, yamlFromValue(c.(map[string]interface{}), comments))
+	}
+	mapping := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map", Content: []*yamlv3.Node{key, value}}
+	if old, ok := comments[id]; ok {
+		for i, y := range []*yamlv3.Node{mapping, key, value} {
+			y.HeadComment = old[i].HeadComment
+			y.LineComment = old[i].LineComment
+			y.FootComment = old[i].FootComment
+		}
+	}
+	return mapping
+}
@@ -0,0 +1,59 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/bmilesp/jsontree"
+	"github.com/stretchr/testify/assert"
+)
+
+var testJsonTree = `{"a":[{"b":[{"c":[]},{"d":[{"e":[{"f":[]},{"g":[]},{"h":[]},{"i":[{"j":[]},{"k":[]},{"l":[]}]}]}]}]},{"m":[]},{"n":[]}]}`
+
+func TestMarshal(t *testing.T) {
+	res, _ := Marshal(`{"a":[{"b":[{"c":[]}]},{"m":[]}]}`)
+	assert.Equal(t, `a:
+  - b:
+      - c: []
+  - m: []
+`, res)
+
+	res, _ = Marshal(testJsonTree)
+	back, err := Unmarshal(res)
+	assert.NoError(t, err)
+	assert.Equal(t, testJsonTree, back)
+}
+
+func TestUnmarshal(t *testing.T) {
+	res, _ := Unmarshal("a:\n  - b:\n  - 1: []\n  - m:\n    - n: []\n")
+	assert.Equal(t, `{"a":[{"b":[]},{"1":[]},{"m":[{"n":[]}]}]}`, res)
+
+	id, _ := jsontree.GetParentId(res, "n")
+	assert.Equal(t, "m", id)
+
+	_, err := Unmarshal("a:\n  b: []\n")
+	assert.Error(t, err)
+
+	_, err = Unmarshal("a: []\nb: []\n")
+	assert.Error(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	original := `# site menu
+a:
+  - b: [] # first
+  # the m section
+  - m: []
+`
+	tree, _ := Unmarshal(original)
+	tree, _ = jsontree.RemoveById(tree, "b")
+	tree, _ = jsontree.AddIntoLeafById(tree, "m", `{"x":[]}`, "insideEnd")
+
+	res, err := Update(original, tree)
+	assert.NoError(t, err)
+	assert.Equal(t, `# site menu
+a:
+  # the m section
+  - m:
+      - x: []
+`, res)
+}